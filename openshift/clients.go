@@ -0,0 +1,174 @@
+package openshift
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	apps_v1 "github.com/openshift/client-go/apps/clientset/versioned/typed/apps/v1"
+	build_v1 "github.com/openshift/client-go/build/clientset/versioned/typed/build/v1"
+	config_v1 "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
+	image_v1 "github.com/openshift/client-go/image/clientset/versioned/typed/image/v1"
+	oauth_v1 "github.com/openshift/client-go/oauth/clientset/versioned/typed/oauth/v1"
+	project_v1 "github.com/openshift/client-go/project/clientset/versioned/typed/project/v1"
+	route_v1 "github.com/openshift/client-go/route/clientset/versioned/typed/route/v1"
+	security_v1 "github.com/openshift/client-go/security/clientset/versioned/typed/security/v1"
+	user_v1 "github.com/openshift/client-go/user/clientset/versioned/typed/user/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// openshiftClients holds the typed clients for each of the OpenShift API groups, and a metadata
+// client to list only the object metadata of any resource. All clients share a single HTTP transport and rate limiter.
+type openshiftClients struct {
+	Metadata metadata.Interface
+
+	Apps     apps_v1.AppsV1Interface
+	Build    build_v1.BuildV1Interface
+	Config   config_v1.ConfigV1Interface
+	Image    image_v1.ImageV1Interface
+	OAuth    oauth_v1.OauthV1Interface
+	Project  project_v1.ProjectV1Interface
+	Route    route_v1.RouteV1Interface
+	Security security_v1.SecurityV1Interface
+	User     user_v1.UserV1Interface
+
+	// watch holds the clients used by the informer cache to watch resources. They are created from a copy of
+	// the config without the request_timeout, which would otherwise cut off every watch and force a relist.
+	watch *openshiftClients
+}
+
+// watchClients :: returns the clients to watch resources with, the clients themselves if no separate ones are set
+func (c *openshiftClients) watchClients() *openshiftClients {
+	if c.watch != nil {
+		return c.watch
+	}
+	return c
+}
+
+// getClients :: returns the clients of the connection. It is a variable so tests can inject fake clientsets.
+var getClients = func(ctx context.Context, d *plugin.QueryData) (*openshiftClients, error) {
+	clients, err := getClientsCached(ctx, d, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return clients.(*openshiftClients), nil
+}
+
+var getClientsCached = plugin.HydrateFunc(getClientsUncached).Memoize(memoize.WithCacheKeyFunction(getClientsCacheKey))
+
+// getClientsCacheKey :: cache the clients per kubeconfig context of the connection
+func getClientsCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return fmt.Sprintf("getClients-%s", getMatrixContextName(ctx)), nil
+}
+
+// getClientsUncached :: creates the typed clients for the connection, sharing the transport and rate limiter
+func getClientsUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	restconfig, err := getClient(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// copy the config, as it is cached and shared with other callers
+	config := rest.CopyConfig(restconfig)
+
+	// a rate limiter set on the config is used by every client created from it,
+	// so the QPS and burst apply across all API groups rather than per client
+	if config.RateLimiter == nil {
+		qps, burst := config.QPS, config.Burst
+		if qps == 0 {
+			qps = rest.DefaultQPS
+		}
+		if burst == 0 {
+			burst = rest.DefaultBurst
+		}
+		config.RateLimiter = &loggingRateLimiter{
+			RateLimiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+			logger:      plugin.Logger(ctx),
+			burst:       burst,
+		}
+	}
+
+	clients, err := newOpenShiftClients(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// the watch clients share the rate limiter and transport, as the copied config keeps both
+	if config.Timeout != 0 {
+		watchConfig := rest.CopyConfig(config)
+		watchConfig.Timeout = 0
+		if clients.watch, err = newOpenShiftClients(watchConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	return clients, nil
+}
+
+// newOpenShiftClients :: creates the typed clients from the config, sharing a single HTTP client
+func newOpenShiftClients(config *rest.Config) (*openshiftClients, error) {
+	httpClient, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := &openshiftClients{}
+	if clients.Metadata, err = metadata.NewForConfigAndClient(config, httpClient); err != nil {
+		return nil, err
+	}
+	if clients.Apps, err = apps_v1.NewForConfigAndClient(config, httpClient); err != nil {
+		return nil, err
+	}
+	if clients.Build, err = build_v1.NewForConfigAndClient(config, httpClient); err != nil {
+		return nil, err
+	}
+	if clients.Config, err = config_v1.NewForConfigAndClient(config, httpClient); err != nil {
+		return nil, err
+	}
+	if clients.Image, err = image_v1.NewForConfigAndClient(config, httpClient); err != nil {
+		return nil, err
+	}
+	if clients.OAuth, err = oauth_v1.NewForConfigAndClient(config, httpClient); err != nil {
+		return nil, err
+	}
+	if clients.Project, err = project_v1.NewForConfigAndClient(config, httpClient); err != nil {
+		return nil, err
+	}
+	if clients.Route, err = route_v1.NewForConfigAndClient(config, httpClient); err != nil {
+		return nil, err
+	}
+	if clients.Security, err = security_v1.NewForConfigAndClient(config, httpClient); err != nil {
+		return nil, err
+	}
+	if clients.User, err = user_v1.NewForConfigAndClient(config, httpClient); err != nil {
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+// throttleLogThreshold is the minimum time a request waits on the rate limiter before it is logged
+const throttleLogThreshold = 100 * time.Millisecond
+
+// loggingRateLimiter logs the time requests spend waiting on the client-side rate limiter,
+// to help tune the qps and burst connection config arguments.
+type loggingRateLimiter struct {
+	flowcontrol.RateLimiter
+	logger hclog.Logger
+	burst  int
+}
+
+func (l *loggingRateLimiter) Wait(ctx context.Context) error {
+	start := time.Now()
+	err := l.RateLimiter.Wait(ctx)
+	if wait := time.Since(start); wait >= throttleLogThreshold {
+		l.logger.Info("loggingRateLimiter.Wait", "throttled", wait.String(), "qps", l.QPS(), "burst", l.burst)
+	}
+	return err
+}
@@ -21,11 +21,21 @@ func objectMetadataColumns() []*plugin.Column {
 		{Name: "annotations", Type: proto.ColumnType_JSON, Description: "Annotations is an unstructured key-value map stored with a resource that may be set by external tools to store and retrieve arbitrary metadata."},
 		{Name: "owner_references", Type: proto.ColumnType_JSON, Description: "List of objects depended by this object. If all objects in the list have been deleted, this object will be garbage collected. If this object is managed by a controller, then an entry in this list will point to this controller, with the controller field set to true. There cannot be more than one managing controller."},
 		{Name: "finalizers", Type: proto.ColumnType_JSON, Description: "Must be empty before the object is deleted from the registry. Each entry is an identifier for the responsible component that will remove the entry from the list. If the deletionTimestamp of the object is non-nil, entries in this list can only be removed."},
+		{Name: "label_selector", Type: proto.ColumnType_STRING, Description: "A label selector used to filter the objects server-side, in the Kubernetes selector syntax, e.g. `app=web,tier in (frontend,backend)`.", Transform: transform.FromQual("label_selector")},
+	}
+}
+
+func clusterColumns() []*plugin.Column {
+	return []*plugin.Column{
+		{Name: "context_name", Type: proto.ColumnType_STRING, Description: "Kubeconfig context name of the cluster the resource belongs to.", Transform: transform.FromMatrixItem(matrixKeyContextName).Transform(transform.NullIfZeroValue)},
+		{Name: "cluster_name", Type: proto.ColumnType_STRING, Description: "Kubeconfig cluster name of the cluster the resource belongs to.", Transform: transform.FromMatrixItem(matrixKeyClusterName).Transform(transform.NullIfZeroValue)},
+		{Name: "cluster_server", Type: proto.ColumnType_STRING, Description: "URL of the API server of the cluster the resource belongs to.", Transform: transform.FromMatrixItem(matrixKeyClusterServer).Transform(transform.NullIfZeroValue)},
 	}
 }
 
 func commonColumns(columns []*plugin.Column) []*plugin.Column {
 	allColumns := objectMetadataColumns()
 	allColumns = append(allColumns, columns...)
+	allColumns = append(allColumns, clusterColumns()...)
 	return allColumns
 }
@@ -5,8 +5,34 @@ import (
 )
 
 type openshiftConfig struct {
-	ConfigPath    *string `hcl:"config_path"`
-	ConfigContext *string `hcl:"config_context"`
+	AuthMode                 *string  `hcl:"auth_mode"`
+	ConfigPath               *string  `hcl:"config_path"`
+	ConfigPaths              []string `hcl:"config_paths,optional"`
+	ConfigContext            *string  `hcl:"config_context"`
+	ConfigContexts           []string `hcl:"config_contexts,optional"`
+	Host                     *string  `hcl:"host"`
+	Token                    *string  `hcl:"token"`
+	TokenFile                *string  `hcl:"token_file"`
+	ClientCertificate        *string  `hcl:"client_certificate"`
+	ClientCertificateData    *string  `hcl:"client_certificate_data"`
+	ClientKey                *string  `hcl:"client_key"`
+	ClientKeyData            *string  `hcl:"client_key_data"`
+	CertificateAuthority     *string  `hcl:"certificate_authority"`
+	CertificateAuthorityData *string  `hcl:"certificate_authority_data"`
+	InsecureSkipTLSVerify    *bool    `hcl:"insecure_skip_tls_verify"`
+	QPS                      *float64 `hcl:"qps"`
+	Burst                    *int     `hcl:"burst"`
+	RequestTimeout           *string  `hcl:"request_timeout"`
+	ProxyURL                 *string  `hcl:"proxy_url"`
+	ImpersonateUser          *string  `hcl:"impersonate_user"`
+	ImpersonateGroups        []string `hcl:"impersonate_groups,optional"`
+	ImpersonateUID           *string  `hcl:"impersonate_uid"`
+	ForbiddenMode            *string  `hcl:"forbidden_mode"`
+	ListConsistency          *string  `hcl:"list_consistency"`
+	InformerCache            *bool    `hcl:"informer_cache"`
+	InformerIdleTimeout      *string  `hcl:"informer_idle_timeout"`
+	Namespaces               []string `hcl:"namespaces,optional"`
+	ExcludeNamespaces        []string `hcl:"exclude_namespaces,optional"`
 }
 
 func ConfigInstance() interface{} {
@@ -2,17 +2,36 @@ package openshift
 
 import (
 	"context"
-	"strings"
+	"errors"
+	"net/http"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// errorPredicate classifies an error returned by the OpenShift API, e.g. apierrors.IsNotFound
+type errorPredicate func(error) bool
+
+// defaultIgnoreErrors are ignored by all tables, unless the table overrides its ignore config
+var defaultIgnoreErrors = []errorPredicate{
+	apierrors.IsNotFound,
+}
+
+// defaultRetryErrors are retried by all tables, unless the table overrides its retry config
+var defaultRetryErrors = []errorPredicate{
+	apierrors.IsTooManyRequests,
+	apierrors.IsServerTimeout,
+	apierrors.IsTimeout,
+	isTooLargeResourceVersionError,
+	isTransientServerError,
+}
+
 // shouldIgnoreErrors:: function which returns an ErrorPredicate for openshift API calls
-func shouldIgnoreErrors(notFoundErrors []string) plugin.ErrorPredicateWithContext {
+func shouldIgnoreErrors(predicates ...errorPredicate) plugin.ErrorPredicateWithContext {
 	return func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, err error) bool {
-		for _, pattern := range notFoundErrors {
-			// handle not found error
-			if strings.Contains(err.Error(), pattern) {
+		for _, predicate := range predicates {
+			if predicate(err) {
 				return true
 			}
 		}
@@ -20,15 +39,66 @@ func shouldIgnoreErrors(notFoundErrors []string) plugin.ErrorPredicateWithContex
 	}
 }
 
-func shouldRetryError(retryErrors []string) plugin.ErrorPredicateWithContext {
+// shouldRetryError :: function which returns an ErrorPredicate for retrying openshift API calls.
+// The Retry-After header of 429 and 5xx responses is already honoured by the client-go rest client, which waits
+// and retries the request itself before returning the error, so the SDK only applies its own backoff on top.
+func shouldRetryError(predicates ...errorPredicate) plugin.ErrorPredicateWithContext {
 	return func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, err error) bool {
-
-		for _, pattern := range retryErrors {
-			// handle retry error
-			if strings.Contains(err.Error(), pattern) {
+		for _, predicate := range predicates {
+			if predicate(err) {
 				return true
 			}
 		}
 		return false
 	}
 }
+
+// isTransientServerError :: checks for a 500, 502, 503 or 504 response from the API server, which may succeed on retry.
+// Other 5xx responses, e.g. 501 Not Implemented, fail the same way every time.
+func isTransientServerError(err error) bool {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return false
+	}
+	switch status.Status().Code {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// isTooLargeResourceVersionError :: checks for the error returned when a list is requested at a
+// resource version the API server's watch cache has not caught up with yet
+func isTooLargeResourceVersionError(err error) bool {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return false
+	}
+	details := status.Status().Details
+	if details == nil {
+		return false
+	}
+	for _, cause := range details.Causes {
+		if cause.Type == metav1.CauseTypeResourceVersionTooLarge {
+			return true
+		}
+	}
+	return false
+}
+
+// isExpiredError :: checks for the 410 error returned when the continue token of a list has expired,
+// as the API server has compacted the resource version the list started at
+func isExpiredError(err error) bool {
+	return apierrors.IsResourceExpired(err) || apierrors.IsGone(err)
+}
+
+// getInconsistentContinueToken :: returns the continue token the API server may return with an expired error,
+// which resumes the list at the latest resource version, empty if not set
+func getInconsistentContinueToken(err error) string {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return ""
+	}
+	return status.Status().ListMeta.Continue
+}
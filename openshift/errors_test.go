@@ -0,0 +1,99 @@
+package openshift
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	buildv1 "github.com/openshift/api/build/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestShouldIgnoreErrors(t *testing.T) {
+	builds := buildv1.Resource("builds")
+
+	tests := []struct {
+		name       string
+		predicates []errorPredicate
+		err        error
+		want       bool
+	}{
+		{name: "not found", predicates: defaultIgnoreErrors, err: apierrors.NewNotFound(builds, "b1"), want: true},
+		{name: "wrapped not found", predicates: defaultIgnoreErrors, err: fmt.Errorf("get: %w", apierrors.NewNotFound(builds, "b1")), want: true},
+		{name: "forbidden", predicates: defaultIgnoreErrors, err: apierrors.NewForbidden(builds, "b1", errors.New("denied"))},
+		{name: "forbidden with override", predicates: []errorPredicate{apierrors.IsNotFound, apierrors.IsForbidden}, err: apierrors.NewForbidden(builds, "b1", errors.New("denied")), want: true},
+		{name: "other error", predicates: defaultIgnoreErrors, err: errors.New("connection refused")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := shouldIgnoreErrors(test.predicates...)(newTestContext(), nil, nil, test.err); got != test.want {
+				t.Errorf("shouldIgnoreErrors() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestShouldRetryError(t *testing.T) {
+	builds := buildv1.Resource("builds")
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "too many requests", err: apierrors.NewTooManyRequests("slow down", 0), want: true},
+		{name: "server timeout", err: apierrors.NewServerTimeout(builds, "list", 0), want: true},
+		{name: "timeout", err: apierrors.NewTimeoutError("timeout", 0), want: true},
+		{name: "internal error", err: apierrors.NewInternalError(errors.New("etcd unavailable")), want: true},
+		{name: "service unavailable", err: apierrors.NewServiceUnavailable("unavailable"), want: true},
+		{name: "bad gateway", err: apierrors.NewGenericServerResponse(http.StatusBadGateway, "list", builds, "", "", 0, false), want: true},
+		{name: "gateway timeout", err: apierrors.NewGenericServerResponse(http.StatusGatewayTimeout, "list", builds, "", "", 0, false), want: true},
+		{name: "not implemented", err: apierrors.NewGenericServerResponse(http.StatusNotImplemented, "list", builds, "", "", 0, false)},
+		{name: "http version not supported", err: apierrors.NewGenericServerResponse(http.StatusHTTPVersionNotSupported, "list", builds, "", "", 0, false)},
+		{name: "too large resource version", err: &apierrors.StatusError{ErrStatus: v1.Status{Code: 504, Details: &v1.StatusDetails{Causes: []v1.StatusCause{{Type: v1.CauseTypeResourceVersionTooLarge}}}}}, want: true},
+		{name: "not found", err: apierrors.NewNotFound(builds, "b1")},
+		{name: "forbidden", err: apierrors.NewForbidden(builds, "b1", errors.New("denied"))},
+		{name: "bad request", err: apierrors.NewBadRequest("invalid field selector")},
+		{name: "expired continue token", err: apierrors.NewResourceExpired("too old")},
+		{name: "other error", err: errors.New("connection refused")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := shouldRetryError(defaultRetryErrors...)(newTestContext(), nil, nil, test.err); got != test.want {
+				t.Errorf("shouldRetryError() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetInconsistentContinueToken(t *testing.T) {
+	expired := apierrors.NewResourceExpired("too old")
+	expired.ErrStatus.ListMeta.Continue = "inconsistent"
+
+	tests := []struct {
+		name        string
+		err         error
+		wantExpired bool
+		want        string
+	}{
+		{name: "with token", err: expired, wantExpired: true, want: "inconsistent"},
+		{name: "without token", err: apierrors.NewResourceExpired("too old"), wantExpired: true},
+		{name: "gone", err: apierrors.NewGone("gone"), wantExpired: true},
+		{name: "other error", err: errors.New("connection refused")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isExpiredError(test.err); got != test.wantExpired {
+				t.Errorf("isExpiredError() = %v, want %v", got, test.wantExpired)
+			}
+			if got := getInconsistentContinueToken(test.err); got != test.want {
+				t.Errorf("getInconsistentContinueToken() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
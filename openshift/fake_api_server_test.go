@@ -0,0 +1,341 @@
+package openshift
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// fakeResource is a resource served by the fake API server
+type fakeResource struct {
+	group      string
+	resource   string
+	kind       string
+	namespaced bool
+	// fieldPaths maps the field selectors of the resource to the path of their value, where they differ
+	fieldPaths map[string]string
+}
+
+// fakeResources are the OpenShift resources served by the fake API server
+var fakeResources = []fakeResource{
+	{group: "apps.openshift.io", resource: "deploymentconfigs", kind: "DeploymentConfig", namespaced: true},
+	{group: "build.openshift.io", resource: "builds", kind: "Build", namespaced: true, fieldPaths: map[string]string{"status": "status.phase"}},
+	{group: "build.openshift.io", resource: "buildconfigs", kind: "BuildConfig", namespaced: true},
+	{group: "config.openshift.io", resource: "clusteroperators", kind: "ClusterOperator"},
+	{group: "config.openshift.io", resource: "clusterversions", kind: "ClusterVersion"},
+	{group: "config.openshift.io", resource: "apiservers", kind: "APIServer"},
+	{group: "config.openshift.io", resource: "authentications", kind: "Authentication"},
+	{group: "config.openshift.io", resource: "consoles", kind: "Console"},
+	{group: "config.openshift.io", resource: "dnses", kind: "DNS"},
+	{group: "config.openshift.io", resource: "featuregates", kind: "FeatureGate"},
+	{group: "config.openshift.io", resource: "images", kind: "Image"},
+	{group: "config.openshift.io", resource: "infrastructures", kind: "Infrastructure"},
+	{group: "config.openshift.io", resource: "ingresses", kind: "Ingress"},
+	{group: "config.openshift.io", resource: "networks", kind: "Network"},
+	{group: "config.openshift.io", resource: "oauths", kind: "OAuth"},
+	{group: "config.openshift.io", resource: "proxies", kind: "Proxy"},
+	{group: "config.openshift.io", resource: "schedulers", kind: "Scheduler"},
+	{group: "image.openshift.io", resource: "imagestreams", kind: "ImageStream", namespaced: true},
+	{group: "route.openshift.io", resource: "routes", kind: "Route", namespaced: true},
+	{group: "oauth.openshift.io", resource: "oauthaccesstokens", kind: "OAuthAccessToken"},
+	{group: "project.openshift.io", resource: "projects", kind: "Project"},
+	{group: "security.openshift.io", resource: "securitycontextconstraints", kind: "SecurityContextConstraints"},
+	{group: "user.openshift.io", resource: "users", kind: "User"},
+}
+
+// fakeRequest is a request received by the fake API server
+type fakeRequest struct {
+	resource  string
+	namespace string
+	name      string
+	query     url.Values
+	// metadataOnly is set for requests of the metadata client, which accept a PartialObjectMetadataList
+	metadataOnly bool
+}
+
+// fakeFailure makes the fake API server fail the matching requests with the status code, a number of times
+type fakeFailure struct {
+	match func(request fakeRequest) bool
+	code  int32
+	times int
+}
+
+// fakeAPIServer is a local stand-in for the OpenShift API, serving discovery, gets and paged lists of
+// fixture objects, with label and field selectors, and failing requests on demand
+type fakeAPIServer struct {
+	*httptest.Server
+
+	lock     sync.Mutex
+	objects  map[string][]map[string]interface{}
+	pageSize int
+	requests []fakeRequest
+	failures []*fakeFailure
+}
+
+// newFakeAPIServer :: starts a fake API server serving the objects, in pages of up to pageSize items
+func newFakeAPIServer(t *testing.T, pageSize int, objects ...runtime.Object) *fakeAPIServer {
+	t.Helper()
+
+	server := &fakeAPIServer{objects: map[string][]map[string]interface{}{}, pageSize: pageSize}
+	for _, object := range objects {
+		resource := fakeResourceForObject(t, object)
+		// objects are converted through JSON, as the unstructured converter does not support nil times without omitempty
+		data, err := json.Marshal(object)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content := map[string]interface{}{}
+		if err := json.Unmarshal(data, &content); err != nil {
+			t.Fatal(err)
+		}
+		content["apiVersion"] = resource.group + "/v1"
+		content["kind"] = resource.kind
+		server.objects[resource.resource] = append(server.objects[resource.resource], content)
+	}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func fakeResourceForObject(t *testing.T, object runtime.Object) fakeResource {
+	t.Helper()
+
+	kind := reflect.TypeOf(object).Elem().Name()
+	for _, resource := range fakeResources {
+		if resource.kind == kind {
+			return resource
+		}
+	}
+	t.Fatalf("fake API server does not serve %T", object)
+	return fakeResource{}
+}
+
+// fail :: fails the next times requests matching the function with the status code
+func (s *fakeAPIServer) fail(code int32, times int, match func(request fakeRequest) bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.failures = append(s.failures, &fakeFailure{match: match, code: code, times: times})
+}
+
+// requestsFor :: returns the requests received for the resource
+func (s *fakeAPIServer) requestsFor(resource string) []fakeRequest {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	requests := []fakeRequest{}
+	for _, request := range s.requests {
+		if request.resource == resource {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+func (s *fakeAPIServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/api":
+		writeJSON(w, http.StatusOK, &v1.APIVersions{TypeMeta: v1.TypeMeta{Kind: "APIVersions"}, Versions: []string{"v1"}})
+		return
+	case "/apis":
+		writeJSON(w, http.StatusOK, fakeAPIGroupList())
+		return
+	}
+
+	resource, request, ok := parseFakeRequest(r)
+	if !ok {
+		writeStatus(w, http.StatusNotFound, fmt.Sprintf("the server could not find the requested resource %s", r.URL.Path))
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.requests = append(s.requests, request)
+	for _, failure := range s.failures {
+		if failure.times > 0 && failure.match(request) {
+			failure.times--
+			writeStatus(w, failure.code, fmt.Sprintf("fake failure of %s", r.URL))
+			return
+		}
+	}
+
+	if request.name != "" {
+		for _, object := range s.objects[resource.resource] {
+			item := &unstructured.Unstructured{Object: object}
+			if item.GetName() == request.name && item.GetNamespace() == request.namespace {
+				writeJSON(w, http.StatusOK, object)
+				return
+			}
+		}
+		writeStatus(w, http.StatusNotFound, fmt.Sprintf("%s %q not found", resource.resource, request.name))
+		return
+	}
+
+	s.serveList(w, resource, request)
+}
+
+// serveList :: serves a page of the objects matching the namespace, label selector and field selector of the request
+func (s *fakeAPIServer) serveList(w http.ResponseWriter, resource fakeResource, request fakeRequest) {
+	labelSelector, err := labels.Parse(request.query.Get("labelSelector"))
+	if err != nil {
+		writeStatus(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	fieldSelector, err := fields.ParseSelector(request.query.Get("fieldSelector"))
+	if err != nil {
+		writeStatus(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	items := []map[string]interface{}{}
+	for _, object := range s.objects[resource.resource] {
+		item := &unstructured.Unstructured{Object: object}
+		if request.namespace != "" && item.GetNamespace() != request.namespace {
+			continue
+		}
+		if !labelSelector.Matches(labels.Set(item.GetLabels())) || !fieldSelector.Matches(fakeFieldSet(resource, object, fieldSelector)) {
+			continue
+		}
+		items = append(items, object)
+	}
+	slices.SortFunc(items, func(a, b map[string]interface{}) int {
+		itemA, itemB := &unstructured.Unstructured{Object: a}, &unstructured.Unstructured{Object: b}
+		return strings.Compare(itemA.GetNamespace()+"/"+itemA.GetName(), itemB.GetNamespace()+"/"+itemB.GetName())
+	})
+
+	// continue tokens are the offset of the next page
+	offset := 0
+	if token := request.query.Get("continue"); token != "" {
+		if offset, err = strconv.Atoi(token); err != nil || offset > len(items) {
+			writeStatus(w, http.StatusBadRequest, fmt.Sprintf("invalid continue token %q", token))
+			return
+		}
+	}
+	limit := len(items)
+	if value, err := strconv.Atoi(request.query.Get("limit")); err == nil && value > 0 {
+		limit = value
+	}
+	if s.pageSize > 0 && s.pageSize < limit {
+		limit = s.pageSize
+	}
+	end := min(offset+limit, len(items))
+	continueToken := ""
+	if end < len(items) {
+		continueToken = strconv.Itoa(end)
+	}
+
+	page := items[offset:end]
+	apiVersion, kind := resource.group+"/v1", resource.kind+"List"
+	if request.metadataOnly {
+		apiVersion, kind = "meta.k8s.io/v1", "PartialObjectMetadataList"
+		partialItems := []map[string]interface{}{}
+		for _, item := range page {
+			partialItems = append(partialItems, map[string]interface{}{
+				"apiVersion": apiVersion,
+				"kind":       "PartialObjectMetadata",
+				"metadata":   item["metadata"],
+			})
+		}
+		page = partialItems
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"resourceVersion": "1", "continue": continueToken},
+		"items":      page,
+	})
+}
+
+// fakeFieldSet :: returns the values of the fields of the selector for the object
+func fakeFieldSet(resource fakeResource, object map[string]interface{}, selector fields.Selector) fields.Set {
+	set := fields.Set{}
+	for _, requirement := range selector.Requirements() {
+		path := requirement.Field
+		if alias, ok := resource.fieldPaths[path]; ok {
+			path = alias
+		}
+		value, _, _ := unstructured.NestedString(object, strings.Split(path, ".")...)
+		set[requirement.Field] = value
+	}
+	return set
+}
+
+// parseFakeRequest :: parses a request for /apis/{group}/v1/[namespaces/{namespace}/]{resource}[/{name}]
+func parseFakeRequest(r *http.Request) (fakeResource, fakeRequest, bool) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 4 || parts[0] != "apis" || parts[2] != "v1" {
+		return fakeResource{}, fakeRequest{}, false
+	}
+	group, parts := parts[1], parts[3:]
+
+	request := fakeRequest{
+		query:        r.URL.Query(),
+		metadataOnly: strings.Contains(r.Header.Get("Accept"), "as=PartialObjectMetadataList"),
+	}
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		request.namespace, parts = parts[1], parts[2:]
+	}
+	request.resource = parts[0]
+	if len(parts) == 2 {
+		request.name = parts[1]
+	}
+
+	for _, resource := range fakeResources {
+		if resource.group == group && resource.resource == request.resource {
+			return resource, request, len(parts) <= 2
+		}
+	}
+	return fakeResource{}, fakeRequest{}, false
+}
+
+func fakeAPIGroupList() *v1.APIGroupList {
+	groups := &v1.APIGroupList{TypeMeta: v1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"}}
+	for _, resource := range fakeResources {
+		if slices.ContainsFunc(groups.Groups, func(group v1.APIGroup) bool { return group.Name == resource.group }) {
+			continue
+		}
+		version := v1.GroupVersionForDiscovery{GroupVersion: resource.group + "/v1", Version: "v1"}
+		groups.Groups = append(groups.Groups, v1.APIGroup{Name: resource.group, Versions: []v1.GroupVersionForDiscovery{version}, PreferredVersion: version})
+	}
+	return groups
+}
+
+func writeStatus(w http.ResponseWriter, code int32, message string) {
+	reasons := map[int32]v1.StatusReason{
+		http.StatusBadRequest:      v1.StatusReasonBadRequest,
+		http.StatusForbidden:       v1.StatusReasonForbidden,
+		http.StatusNotFound:        v1.StatusReasonNotFound,
+		http.StatusGone:            v1.StatusReasonExpired,
+		http.StatusTooManyRequests: v1.StatusReasonTooManyRequests,
+	}
+	writeJSON(w, int(code), &v1.Status{
+		TypeMeta: v1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   v1.StatusFailure,
+		Code:     code,
+		Reason:   reasons[code],
+		Message:  message,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
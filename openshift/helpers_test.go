@@ -0,0 +1,206 @@
+package openshift
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	appsv1 "github.com/openshift/api/apps/v1"
+	buildv1 "github.com/openshift/api/build/v1"
+	configv1 "github.com/openshift/api/config/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	oauthv1 "github.com/openshift/api/oauth/v1"
+	projectv1 "github.com/openshift/api/project/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	securityv1 "github.com/openshift/api/security/v1"
+	userv1 "github.com/openshift/api/user/v1"
+	fakeapps "github.com/openshift/client-go/apps/clientset/versioned/fake"
+	fakebuild "github.com/openshift/client-go/build/clientset/versioned/fake"
+	fakeconfig "github.com/openshift/client-go/config/clientset/versioned/fake"
+	fakeimage "github.com/openshift/client-go/image/clientset/versioned/fake"
+	fakeoauth "github.com/openshift/client-go/oauth/clientset/versioned/fake"
+	fakeproject "github.com/openshift/client-go/project/clientset/versioned/fake"
+	fakeroute "github.com/openshift/client-go/route/clientset/versioned/fake"
+	fakesecurity "github.com/openshift/client-go/security/clientset/versioned/fake"
+	fakeuser "github.com/openshift/client-go/user/clientset/versioned/fake"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/context_key"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// fakeClientsets holds the fake clientsets behind the clients returned by newFakeClients, to add reactors
+type fakeClientsets struct {
+	apps     *fakeapps.Clientset
+	build    *fakebuild.Clientset
+	config   *fakeconfig.Clientset
+	image    *fakeimage.Clientset
+	oauth    *fakeoauth.Clientset
+	project  *fakeproject.Clientset
+	route    *fakeroute.Clientset
+	security *fakesecurity.Clientset
+	user     *fakeuser.Clientset
+}
+
+// newFakeClients :: creates clients backed by fake clientsets, seeded with the objects of each API group
+func newFakeClients(t *testing.T, objects ...runtime.Object) (*openshiftClients, *fakeClientsets) {
+	t.Helper()
+
+	grouped := map[string][]runtime.Object{}
+	for _, object := range objects {
+		switch object.(type) {
+		case *appsv1.DeploymentConfig:
+			grouped["apps"] = append(grouped["apps"], object)
+		case *buildv1.Build, *buildv1.BuildConfig:
+			grouped["build"] = append(grouped["build"], object)
+		case *configv1.ClusterOperator, *configv1.ClusterVersion,
+			*configv1.APIServer, *configv1.Authentication, *configv1.Console, *configv1.DNS, *configv1.FeatureGate, *configv1.Image,
+			*configv1.Infrastructure, *configv1.Ingress, *configv1.Network, *configv1.OAuth, *configv1.Proxy, *configv1.Scheduler:
+			grouped["config"] = append(grouped["config"], object)
+		case *imagev1.ImageStream:
+			grouped["image"] = append(grouped["image"], object)
+		case *oauthv1.OAuthAccessToken:
+			grouped["oauth"] = append(grouped["oauth"], object)
+		case *projectv1.Project:
+			grouped["project"] = append(grouped["project"], object)
+		case *routev1.Route:
+			grouped["route"] = append(grouped["route"], object)
+		case *securityv1.SecurityContextConstraints:
+			grouped["security"] = append(grouped["security"], object)
+		case *userv1.User:
+			grouped["user"] = append(grouped["user"], object)
+		default:
+			t.Fatalf("newFakeClients: unsupported object type %T", object)
+		}
+	}
+
+	fakes := &fakeClientsets{
+		apps:     fakeapps.NewSimpleClientset(grouped["apps"]...),
+		build:    fakebuild.NewSimpleClientset(grouped["build"]...),
+		config:   fakeconfig.NewSimpleClientset(grouped["config"]...),
+		image:    fakeimage.NewSimpleClientset(grouped["image"]...),
+		oauth:    fakeoauth.NewSimpleClientset(grouped["oauth"]...),
+		project:  fakeproject.NewSimpleClientset(grouped["project"]...),
+		route:    fakeroute.NewSimpleClientset(grouped["route"]...),
+		security: fakesecurity.NewSimpleClientset(),
+		user:     fakeuser.NewSimpleClientset(grouped["user"]...),
+	}
+	// the object tracker guesses the resource of seeded objects from their kind, which is wrong for
+	// SecurityContextConstraints, so they are added with their resource instead
+	for _, object := range grouped["security"] {
+		if err := fakes.security.Tracker().Create(securityContextConstraintResource.resource, object, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	clients := &openshiftClients{
+		Apps:     fakes.apps.AppsV1(),
+		Build:    fakes.build.BuildV1(),
+		Config:   fakes.config.ConfigV1(),
+		Image:    fakes.image.ImageV1(),
+		OAuth:    fakes.oauth.OauthV1(),
+		Project:  fakes.project.ProjectV1(),
+		Route:    fakes.route.RouteV1(),
+		Security: fakes.security.SecurityV1(),
+		User:     fakes.user.UserV1(),
+	}
+
+	return clients, fakes
+}
+
+// injectClients :: makes getClients return the clients for the duration of the test
+func injectClients(t *testing.T, clients *openshiftClients) {
+	t.Helper()
+
+	original := getClients
+	getClients = func(context.Context, *plugin.QueryData) (*openshiftClients, error) {
+		return clients, nil
+	}
+	t.Cleanup(func() { getClients = original })
+}
+
+// newTestContext :: returns a context with the logger the plugin SDK provides to hydrate functions
+func newTestContext() context.Context {
+	return context.WithValue(context.Background(), context_key.Logger, hclog.NewNullLogger())
+}
+
+// newTestQueryData :: returns the query data of a query with the quals and limit, on a connection with the config
+func newTestQueryData(config openshiftConfig, limit *int64, qualList ...*quals.Qual) *plugin.QueryData {
+	d := &plugin.QueryData{
+		Connection:   &plugin.Connection{Name: "openshift", Config: config},
+		QueryContext: &plugin.QueryContext{Limit: limit},
+		Quals:        plugin.KeyColumnQualMap{},
+		EqualsQuals:  map[string]*proto.QualValue{},
+	}
+	for _, qual := range qualList {
+		if d.Quals[qual.Column] == nil {
+			d.Quals[qual.Column] = &plugin.KeyColumnQuals{Name: qual.Column}
+		}
+		d.Quals[qual.Column].Quals = append(d.Quals[qual.Column].Quals, qual)
+		if qual.Operator == quals.QualOperatorEqual {
+			d.EqualsQuals[qual.Column] = qual.Value
+		}
+	}
+	return d
+}
+
+// newStringQual :: returns a qual comparing the column to a string
+func newStringQual(column string, operator string, value string) *quals.Qual {
+	return &quals.Qual{
+		Column:   column,
+		Operator: operator,
+		Value:    &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: value}},
+	}
+}
+
+// newStringListQual :: returns a qual comparing the column to a list of strings, e.g. namespace in ('a', 'b')
+func newStringListQual(column string, values ...string) *quals.Qual {
+	listValue := &proto.QualValueList{}
+	for _, value := range values {
+		listValue.Values = append(listValue.Values, &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: value}})
+	}
+	return &quals.Qual{
+		Column:   column,
+		Operator: quals.QualOperatorEqual,
+		Value:    &proto.QualValue{Value: &proto.QualValue_ListValue{ListValue: listValue}},
+	}
+}
+
+// testStreamer collects the items streamed by the list helpers, up to an optional limit
+type testStreamer struct {
+	*itemStreamer
+	items []interface{}
+}
+
+// newTestStreamer :: returns a streamer which collects items, and stops once it has limit items if limit is positive
+func newTestStreamer(limit int) *testStreamer {
+	streamer := &testStreamer{}
+	streamer.itemStreamer = &itemStreamer{
+		streamItem: func(item interface{}) {
+			streamer.items = append(streamer.items, item)
+		},
+		rowsRemaining: func() int64 {
+			if limit <= 0 {
+				return 1
+			}
+			return int64(limit - len(streamer.items))
+		},
+	}
+	return streamer
+}
+
+// names :: returns the names of the streamed items
+func (s *testStreamer) names(t *testing.T) []string {
+	t.Helper()
+
+	names := []string{}
+	for _, item := range s.items {
+		object, ok := item.(interface{ GetName() string })
+		if !ok {
+			t.Fatalf("streamed item %T has no name", item)
+		}
+		names = append(names, object.GetName())
+	}
+	return names
+}
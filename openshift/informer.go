@@ -0,0 +1,345 @@
+package openshift
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// defaultInformerIdleTimeout is how long an informer keeps watching a resource after it was last queried
+const defaultInformerIdleTimeout = 10 * time.Minute
+
+// Backoff before an informer which failed its initial list is started again, doubling on each failure
+const (
+	initialInformerBackoff = time.Minute
+	maxInformerBackoff     = 30 * time.Minute
+)
+
+// informers holds the informers started by all connections of the plugin
+var informers = &informerManager{entries: map[string]*informerEntry{}, failures: map[string]*informerFailure{}}
+
+// informerManager starts an informer per connection, kubeconfig context and resource on first use,
+// and stops it once it has not been queried for the idle timeout of the connection.
+type informerManager struct {
+	lock     sync.Mutex
+	entries  map[string]*informerEntry
+	failures map[string]*informerFailure
+}
+
+// informerFailure remembers an informer which failed its initial list, e.g. as listing the resource cluster wide
+// is forbidden, so it is not started again by every query until its backoff has passed
+type informerFailure struct {
+	retryAt time.Time
+	backoff time.Duration
+}
+
+// informerEntry is a running informer, which is available once its initial list has synced
+type informerEntry struct {
+	informer cache.SharedIndexInformer
+	cancel   context.CancelFunc
+	done     <-chan struct{}
+	// lastUsed is the time the informer was last queried, in unix nanoseconds
+	lastUsed atomic.Int64
+
+	synced   chan struct{}
+	failed   chan struct{}
+	failOnce sync.Once
+	err      error
+}
+
+// informerFieldSelectorFields are the fields the field selector of a list can use to be served from the informer cache.
+// Table specific fields, e.g. status or spec.host, are only supported by the API server.
+var informerFieldSelectorFields = []string{"metadata.name", "metadata.namespace"}
+
+// listFromInformer :: streams the items of the resource from the informer cache, if enabled.
+// Returns false if the informer cache is not enabled or not available, or the field selector uses fields other than
+// the name and namespace, so the resource is listed from the API server instead. The selectors must be applied here
+// rather than left to Postgres, as items streamed count towards the limit of the query.
+func listFromInformer(ctx context.Context, d *plugin.QueryData, streamer *itemStreamer, source resourceSource, input v1.ListOptions, namespaces []string) (bool, error) {
+	fieldSelector, err := fields.ParseSelector(input.FieldSelector)
+	if err != nil {
+		return false, nil
+	}
+	for _, requirement := range fieldSelector.Requirements() {
+		if !slices.Contains(informerFieldSelectorFields, requirement.Field) {
+			return false, nil
+		}
+	}
+
+	store, ok := getInformerStore(ctx, d, source)
+	if !ok {
+		return false, nil
+	}
+
+	selector, err := labels.Parse(input.LabelSelector)
+	if err != nil {
+		return true, err
+	}
+
+	items := []interface{}{}
+	if len(namespaces) == 0 {
+		items = store.List()
+	}
+	for _, namespace := range namespaces {
+		namespaceItems, err := store.ByIndex(cache.NamespaceIndex, namespace)
+		if err != nil {
+			return true, err
+		}
+		items = append(items, namespaceItems...)
+	}
+
+	for _, item := range items {
+		object, err := meta.Accessor(item)
+		if err != nil {
+			return true, err
+		}
+		if !selector.Matches(labels.Set(object.GetLabels())) {
+			continue
+		}
+		if !fieldSelector.Matches(fields.Set{"metadata.name": object.GetName(), "metadata.namespace": object.GetNamespace()}) {
+			continue
+		}
+		if !streamer.stream(item.(runtime.Object)) {
+			break
+		}
+	}
+
+	return true, nil
+}
+
+// getInformerStore :: returns the synced store of the informer for the resource, if the informer cache is enabled,
+// starting the informer and waiting for its initial list if not already running. Informers which fail their
+// initial list, e.g. as listing the resource cluster wide is forbidden, are not used until their backoff has passed.
+func getInformerStore(ctx context.Context, d *plugin.QueryData, source resourceSource) (cache.Indexer, bool) {
+	key, idleTimeout, ok := getInformerKey(ctx, d, source)
+	if !ok {
+		return nil, false
+	}
+
+	entry, ok := informers.get(ctx, key, source, idleTimeout)
+	if !ok {
+		return nil, false
+	}
+	if err := entry.waitForSync(ctx); err != nil {
+		// a cancelled query only stops waiting, the informer keeps syncing for other queries
+		if ctx.Err() != nil {
+			return nil, false
+		}
+		plugin.Logger(ctx).Warn("getInformerStore", "informer_error", err, "resource", source.resource.String())
+		informers.fail(key, entry)
+		return nil, false
+	}
+	informers.succeed(key)
+
+	return entry.informer.GetIndexer(), true
+}
+
+// getSyncedInformerStore :: returns the store of the informer for the resource, only if it is already running and synced.
+// Gets of a single object are cheaper from the API server than starting an informer for the whole resource.
+func getSyncedInformerStore(ctx context.Context, d *plugin.QueryData, source resourceSource) (cache.Indexer, bool) {
+	key, _, ok := getInformerKey(ctx, d, source)
+	if !ok {
+		return nil, false
+	}
+
+	entry, ok := informers.lookup(key)
+	if !ok {
+		return nil, false
+	}
+	select {
+	case <-entry.synced:
+	default:
+		return nil, false
+	}
+	entry.lastUsed.Store(time.Now().UnixNano())
+
+	return entry.informer.GetIndexer(), true
+}
+
+// getInformerKey :: returns the key of the informer for the resource and the idle timeout of the connection,
+// false if the informer cache is not enabled
+func getInformerKey(ctx context.Context, d *plugin.QueryData, source resourceSource) (string, time.Duration, bool) {
+	openshiftConfig := GetConfig(d.Connection)
+	if openshiftConfig.InformerCache == nil || !*openshiftConfig.InformerCache {
+		return "", 0, false
+	}
+	idleTimeout, err := getInformerIdleTimeout(openshiftConfig)
+	if err != nil {
+		// the error is returned by the client when the connection is created
+		return "", 0, false
+	}
+
+	return fmt.Sprintf("%s-%s-%s", d.Connection.Name, getMatrixContextName(ctx), source.resource.String()), idleTimeout, true
+}
+
+// get :: returns the informer for the key, starting it if not already running.
+// Returns false if the informer failed recently and its backoff has not passed yet.
+func (m *informerManager) get(ctx context.Context, key string, source resourceSource, idleTimeout time.Duration) (*informerEntry, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok {
+		if failure, failed := m.failures[key]; failed && time.Now().Before(failure.retryAt) {
+			return nil, false
+		}
+		entry = newInformerEntry(ctx, source)
+		m.entries[key] = entry
+		go m.stopWhenIdle(key, entry, idleTimeout)
+	}
+	entry.lastUsed.Store(time.Now().UnixNano())
+
+	return entry, true
+}
+
+// lookup :: returns the informer for the key, if running
+func (m *informerManager) lookup(key string) (*informerEntry, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	entry, ok := m.entries[key]
+	return entry, ok
+}
+
+// fail :: stops the failed informer and backs off before it is started again, doubling the backoff of previous failures.
+// Queries waiting on the same informer all report its failure, which only counts once.
+func (m *informerManager) fail(key string, entry *informerEntry) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	entry.cancel()
+	if m.entries[key] != entry {
+		return
+	}
+	delete(m.entries, key)
+
+	backoff := initialInformerBackoff
+	if failure, ok := m.failures[key]; ok {
+		backoff = min(failure.backoff*2, maxInformerBackoff)
+	}
+	m.failures[key] = &informerFailure{retryAt: time.Now().Add(backoff), backoff: backoff}
+}
+
+// succeed :: resets the backoff of the informer once it has synced
+func (m *informerManager) succeed(key string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.failures, key)
+}
+
+// remove :: stops the informer and removes it, unless it has already been replaced
+func (m *informerManager) remove(key string, entry *informerEntry) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.entries[key] == entry {
+		delete(m.entries, key)
+	}
+	entry.cancel()
+}
+
+// stopWhenIdle :: stops the informer once it has not been queried for the idle timeout
+func (m *informerManager) stopWhenIdle(key string, entry *informerEntry, idleTimeout time.Duration) {
+	ticker := time.NewTicker(idleTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-entry.done:
+			return
+		case <-ticker.C:
+			if time.Since(time.Unix(0, entry.lastUsed.Load())) >= idleTimeout {
+				m.remove(key, entry)
+				return
+			}
+		}
+	}
+}
+
+// newInformerEntry :: starts an informer listing and watching the resource across all namespaces
+func newInformerEntry(ctx context.Context, source resourceSource) *informerEntry {
+	// the informer outlives the query which started it
+	informerCtx, cancel := context.WithCancel(context.Background())
+	listWatch := &cache.ListWatch{
+		ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+			return source.list(informerCtx, "", options)
+		},
+		WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+			return source.watch(informerCtx, "", options)
+		},
+	}
+
+	entry := &informerEntry{
+		informer: cache.NewSharedIndexInformer(listWatch, source.object, 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
+		cancel:   cancel,
+		done:     informerCtx.Done(),
+		synced:   make(chan struct{}),
+		failed:   make(chan struct{}),
+	}
+
+	logger := plugin.Logger(ctx)
+	_ = entry.informer.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
+		select {
+		case <-entry.synced:
+			// the reflector relists and rewatches on its own once synced
+			logger.Warn("informerEntry.watch", "resource", source.resource.String(), "watch_error", err)
+		default:
+			entry.failOnce.Do(func() {
+				entry.err = err
+				close(entry.failed)
+			})
+		}
+	})
+
+	logger.Info("newInformerEntry", "resource", source.resource.String())
+	go entry.informer.Run(entry.done)
+	go func() {
+		if cache.WaitForCacheSync(entry.done, entry.informer.HasSynced) {
+			close(entry.synced)
+		}
+	}()
+
+	return entry
+}
+
+// waitForSync :: waits for the initial list of the informer, returns an error if it failed
+func (e *informerEntry) waitForSync(ctx context.Context) error {
+	select {
+	case <-e.synced:
+		return nil
+	case <-e.failed:
+		return e.err
+	case <-e.done:
+		return fmt.Errorf("informer stopped before it synced")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// getInformerIdleTimeout :: resolves the informer_idle_timeout connection config argument
+func getInformerIdleTimeout(openshiftConfig openshiftConfig) (time.Duration, error) {
+	if openshiftConfig.InformerIdleTimeout == nil {
+		return defaultInformerIdleTimeout, nil
+	}
+	idleTimeout, err := time.ParseDuration(*openshiftConfig.InformerIdleTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid informer_idle_timeout %q: %w", *openshiftConfig.InformerIdleTimeout, err)
+	}
+	if idleTimeout <= 0 {
+		return 0, fmt.Errorf("invalid informer_idle_timeout %q: must be positive", *openshiftConfig.InformerIdleTimeout)
+	}
+	return idleTimeout, nil
+}
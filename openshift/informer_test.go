@@ -0,0 +1,171 @@
+package openshift
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	buildv1 "github.com/openshift/api/build/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// forbiddenBuildsSource :: returns a resource source of builds which cannot be listed or watched, counting the lists
+func forbiddenBuildsSource(lists *int) resourceSource {
+	forbidden := apierrors.NewForbidden(buildv1.Resource("builds"), "", errors.New("denied"))
+	return resourceSource{
+		resource: buildv1.GroupVersion.WithResource("builds"),
+		object:   &buildv1.Build{},
+		list: func(_ context.Context, _ string, _ v1.ListOptions) (runtime.Object, error) {
+			*lists++
+			return nil, forbidden
+		},
+		watch: func(_ context.Context, _ string, _ v1.ListOptions) (watch.Interface, error) {
+			return nil, forbidden
+		},
+	}
+}
+
+func TestInformerManagerBacksOffFailedInformers(t *testing.T) {
+	manager := &informerManager{entries: map[string]*informerEntry{}, failures: map[string]*informerFailure{}}
+	lists := 0
+	source := forbiddenBuildsSource(&lists)
+
+	failInformer := func() {
+		t.Helper()
+		entry, ok := manager.get(newTestContext(), "builds", source, time.Minute)
+		if !ok {
+			t.Fatal("expected the informer to be started")
+		}
+		if err := entry.waitForSync(newTestContext()); !apierrors.IsForbidden(err) {
+			t.Fatalf("expected a forbidden error, got %v", err)
+		}
+		// every query waiting on the informer reports its failure, which only counts once
+		manager.fail("builds", entry)
+		manager.fail("builds", entry)
+	}
+
+	failInformer()
+	if _, ok := manager.get(newTestContext(), "builds", source, time.Minute); ok {
+		t.Error("expected the failed informer not to be started again during its backoff")
+	}
+	if backoff := manager.failures["builds"].backoff; backoff != initialInformerBackoff {
+		t.Errorf("backoff = %s, want %s", backoff, initialInformerBackoff)
+	}
+
+	// once the backoff has passed the informer is started again, doubling the backoff if it fails again
+	manager.failures["builds"].retryAt = time.Now()
+	failInformer()
+	if backoff := manager.failures["builds"].backoff; backoff != 2*initialInformerBackoff {
+		t.Errorf("backoff = %s, want %s", backoff, 2*initialInformerBackoff)
+	}
+
+	manager.succeed("builds")
+	if _, ok := manager.get(newTestContext(), "builds", source, time.Minute); !ok {
+		t.Error("expected the informer to be started once its backoff is reset")
+	}
+	for key, entry := range manager.entries {
+		manager.remove(key, entry)
+	}
+}
+
+func TestGetInformerStoreCancelledQueryDoesNotFailInformer(t *testing.T) {
+	informerCache := true
+	d := newTestQueryData(openshiftConfig{InformerCache: &informerCache}, nil)
+	// the initial list of the informer blocks until the informer is stopped
+	source := resourceSource{
+		resource: buildv1.GroupVersion.WithResource("builds"),
+		object:   &buildv1.Build{},
+		list: func(ctx context.Context, _ string, _ v1.ListOptions) (runtime.Object, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+		watch: func(ctx context.Context, _ string, _ v1.ListOptions) (watch.Interface, error) {
+			return nil, errors.New("not watched")
+		},
+	}
+
+	ctx, cancel := context.WithCancel(newTestContext())
+	cancel()
+	if _, ok := getInformerStore(ctx, d, source); ok {
+		t.Fatal("expected no store for a cancelled query")
+	}
+
+	key, _, _ := getInformerKey(newTestContext(), d, source)
+	entry, ok := informers.lookup(key)
+	if !ok {
+		t.Fatal("expected the informer to keep running for other queries")
+	}
+	if _, failed := informers.failures[key]; failed {
+		t.Error("expected the informer not to back off")
+	}
+	informers.remove(key, entry)
+}
+
+func TestGetSyncedInformerStoreDoesNotStartInformers(t *testing.T) {
+	informerCache := true
+	d := newTestQueryData(openshiftConfig{InformerCache: &informerCache}, nil)
+	lists := 0
+	source := forbiddenBuildsSource(&lists)
+
+	if _, ok := getSyncedInformerStore(newTestContext(), d, source); ok {
+		t.Error("expected no store without a running informer")
+	}
+	key, _, _ := getInformerKey(newTestContext(), d, source)
+	if _, ok := informers.lookup(key); ok || lists != 0 {
+		t.Errorf("expected no informer to be started, listed %d times", lists)
+	}
+}
+
+func TestListFromInformerAppliesFieldSelector(t *testing.T) {
+	clients, _ := newFakeClients(t,
+		&buildv1.Build{ObjectMeta: v1.ObjectMeta{Name: "a", Namespace: "ns"}, Status: buildv1.BuildStatus{Phase: buildv1.BuildPhaseComplete}},
+		&buildv1.Build{ObjectMeta: v1.ObjectMeta{Name: "b", Namespace: "ns"}, Status: buildv1.BuildStatus{Phase: buildv1.BuildPhaseFailed}},
+	)
+	source := buildResource.source(clients)
+	informerCache := true
+	limit := int64(1)
+
+	tests := []struct {
+		name       string
+		qual       *quals.Qual
+		wantServed bool
+		want       []string
+	}{
+		{name: "name", qual: newStringQual("name", quals.QualOperatorEqual, "b"), wantServed: true, want: []string{"b"}},
+		{name: "namespace", qual: newStringQual("namespace", quals.QualOperatorNotEqual, "ns"), wantServed: true, want: []string{}},
+		{name: "table specific field", qual: newStringQual("phase", quals.QualOperatorEqual, "Failed"), want: []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := newTestQueryData(openshiftConfig{InformerCache: &informerCache}, &limit, test.qual)
+			input, err := getListOptions(d, buildSelectorQuals...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			streamer := newTestStreamer(int(limit))
+			served, err := listFromInformer(newTestContext(), d, streamer.itemStreamer, source, input, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if served != test.wantServed {
+				t.Errorf("served from the informer = %v, want %v", served, test.wantServed)
+			}
+			if got := streamer.names(t); !slices.Equal(got, test.want) {
+				t.Errorf("streamed %v, want %v", got, test.want)
+			}
+		})
+	}
+
+	key, _, _ := getInformerKey(newTestContext(), newTestQueryData(openshiftConfig{InformerCache: &informerCache}, nil), source)
+	if entry, ok := informers.lookup(key); ok {
+		informers.remove(key, entry)
+	}
+}
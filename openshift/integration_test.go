@@ -0,0 +1,794 @@
+package openshift
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	buildv1 "github.com/openshift/api/build/v1"
+	configv1 "github.com/openshift/api/config/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	oauthv1 "github.com/openshift/api/oauth/v1"
+	projectv1 "github.com/openshift/api/project/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	securityv1 "github.com/openshift/api/security/v1"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	grpcapi "google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// testConnectionName is the name of the connection of the plugin servers started by newTestPluginServer
+const testConnectionName = "openshift"
+
+// newTestPluginServer :: starts the plugin in process, with a connection authenticating to the fake API server
+// with a token, plus the extra HCL arguments of the connection config
+func newTestPluginServer(t *testing.T, api *fakeAPIServer, extraConfig string) *grpc.PluginServer {
+	t.Helper()
+
+	server := plugin.Server(&plugin.ServeOpts{PluginFunc: Plugin})
+	config := fmt.Sprintf("host = %q\ntoken = \"test-token\"\n%s", api.URL, extraConfig)
+	response, err := server.SetAllConnectionConfigs(&proto.SetAllConnectionConfigsRequest{
+		Configs: []*proto.ConnectionConfig{{
+			Connection:      testConnectionName,
+			Plugin:          "hub.steampipe.io/plugins/turbot/openshift@latest",
+			PluginShortName: "openshift",
+			Config:          config,
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed := response.FailedConnections[testConnectionName]; failed != "" {
+		t.Fatalf("invalid connection config: %s", failed)
+	}
+	return server
+}
+
+// testQual is an equal qual of a query, e.g. {"namespace", "ns1"}
+type testQual struct {
+	column string
+	value  string
+}
+
+// testCallID makes the call ID of each query unique
+var testCallID atomic.Int64
+
+// queryTable :: executes a query of the table through the plugin SDK, returning the values of the columns of each row
+func queryTable(t *testing.T, server *grpc.PluginServer, table string, columns []string, quals []testQual, limit int64) ([]map[string]interface{}, error) {
+	t.Helper()
+
+	queryQuals := map[string]*proto.Quals{}
+	for _, qual := range quals {
+		if queryQuals[qual.column] == nil {
+			queryQuals[qual.column] = &proto.Quals{}
+		}
+		queryQuals[qual.column].Quals = append(queryQuals[qual.column].Quals, &proto.Qual{
+			FieldName: qual.column,
+			Operator:  &proto.Qual_StringValue{StringValue: "="},
+			Value:     &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: qual.value}},
+		})
+	}
+	var queryLimit *proto.NullableInt
+	if limit > 0 {
+		queryLimit = &proto.NullableInt{Value: limit}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	stream := &testExecuteStream{ctx: ctx}
+	err := server.Execute(&proto.ExecuteRequest{
+		Table:        table,
+		QueryContext: &proto.QueryContext{Columns: columns, Quals: queryQuals, Limit: queryLimit},
+		CallId:       fmt.Sprintf("%s-%d", t.Name(), testCallID.Add(1)),
+		ExecuteConnectionData: map[string]*proto.ExecuteConnectionData{
+			testConnectionName: {Limit: queryLimit, CacheEnabled: false},
+		},
+	}, stream)
+	return stream.rows, err
+}
+
+// testExecuteStream collects the rows the plugin streams for a query
+type testExecuteStream struct {
+	grpcapi.ServerStream
+
+	ctx  context.Context
+	lock sync.Mutex
+	rows []map[string]interface{}
+}
+
+func (s *testExecuteStream) Send(response *proto.ExecuteResponse) error {
+	if response == nil || response.Row == nil {
+		return nil
+	}
+
+	row := map[string]interface{}{}
+	for name, column := range response.Row.Columns {
+		row[name] = testColumnValue(column)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.rows = append(s.rows, row)
+	return nil
+}
+
+func (s *testExecuteStream) Context() context.Context {
+	return s.ctx
+}
+
+// testColumnValue :: converts a column of a row to its Go value, JSON columns are kept as their JSON text
+func testColumnValue(column *proto.Column) interface{} {
+	switch value := column.Value.(type) {
+	case *proto.Column_StringValue:
+		return value.StringValue
+	case *proto.Column_IntValue:
+		return value.IntValue
+	case *proto.Column_DoubleValue:
+		return value.DoubleValue
+	case *proto.Column_BoolValue:
+		return value.BoolValue
+	case *proto.Column_JsonValue:
+		return string(value.JsonValue)
+	case *proto.Column_TimestampValue:
+		return value.TimestampValue.AsTime()
+	default:
+		return nil
+	}
+}
+
+// rowNames :: returns the sorted namespace/name, or name if cluster scoped, of each row
+func rowNames(rows []map[string]interface{}) []string {
+	names := []string{}
+	for _, row := range rows {
+		name, _ := row["name"].(string)
+		if namespace, _ := row["namespace"].(string); namespace != "" {
+			name = namespace + "/" + name
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// testObjectMeta :: returns the metadata of a fixture object
+func testObjectMeta(namespace, name string, labels map[string]string) v1.ObjectMeta {
+	return v1.ObjectMeta{
+		Namespace:         namespace,
+		Name:              name,
+		UID:               types.UID("uid-" + namespace + "-" + name),
+		Labels:            labels,
+		CreationTimestamp: v1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+	}
+}
+
+func testRoutes() []*routev1.Route {
+	return []*routev1.Route{
+		{ObjectMeta: testObjectMeta("ns1", "api", nil), Spec: routev1.RouteSpec{Host: "api.example.com"}},
+		{ObjectMeta: testObjectMeta("ns1", "web", nil), Spec: routev1.RouteSpec{Host: "web.example.com"}},
+		{ObjectMeta: testObjectMeta("ns2", "admin", nil), Spec: routev1.RouteSpec{Host: "admin.example.com"}},
+		{ObjectMeta: testObjectMeta("ns2", "docs", nil), Spec: routev1.RouteSpec{Host: "docs.example.com"}},
+		{ObjectMeta: testObjectMeta("ns3", "shop", nil), Spec: routev1.RouteSpec{Host: "shop.example.com"}},
+	}
+}
+
+func testBuild(namespace, name, buildConfig string, phase buildv1.BuildPhase) *buildv1.Build {
+	return &buildv1.Build{
+		ObjectMeta: testObjectMeta(namespace, name, map[string]string{"openshift.io/build-config.name": buildConfig}),
+		Status: buildv1.BuildStatus{
+			Phase:  phase,
+			Config: &corev1.ObjectReference{Name: buildConfig, Namespace: namespace},
+		},
+	}
+}
+
+func TestIntegrationListPagesWithContinueTokens(t *testing.T) {
+	objects := []runtime.Object{}
+	for _, route := range testRoutes() {
+		objects = append(objects, route)
+	}
+	api := newFakeAPIServer(t, 2, objects...)
+	server := newTestPluginServer(t, api, "")
+
+	rows, err := queryTable(t, server, "openshift_route", []string{"name", "namespace", "host", "creation_timestamp", "cluster_server"}, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"ns1/api", "ns1/web", "ns2/admin", "ns2/docs", "ns3/shop"}
+	if names := rowNames(rows); !slices.Equal(names, expected) {
+		t.Errorf("expected routes %v, got %v", expected, names)
+	}
+	requests := api.requestsFor("routes")
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 pages of routes, got %d requests", len(requests))
+	}
+	if requests[0].query.Get("continue") != "" || requests[1].query.Get("continue") != "2" || requests[2].query.Get("continue") != "4" {
+		t.Errorf("expected the pages to follow the continue tokens, got %v", requests)
+	}
+
+	for _, row := range rows {
+		if row["name"] == "api" {
+			if row["host"] != "api.example.com" {
+				t.Errorf("expected host api.example.com, got %v", row["host"])
+			}
+			if created, _ := row["creation_timestamp"].(time.Time); !created.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+				t.Errorf("expected creation_timestamp 2024-01-02T03:04:05Z, got %v", row["creation_timestamp"])
+			}
+			if row["cluster_server"] != api.URL {
+				t.Errorf("expected cluster_server %s, got %v", api.URL, row["cluster_server"])
+			}
+		}
+	}
+}
+
+func TestIntegrationQualPushdown(t *testing.T) {
+	api := newFakeAPIServer(t, 0,
+		testBuild("ns1", "app-1", "app", buildv1.BuildPhaseComplete),
+		testBuild("ns1", "app-2", "app", buildv1.BuildPhaseFailed),
+		testBuild("ns1", "worker-1", "worker", buildv1.BuildPhaseComplete),
+		testBuild("ns2", "app-1", "app", buildv1.BuildPhaseComplete),
+	)
+	server := newTestPluginServer(t, api, "")
+
+	quals := []testQual{{"namespace", "ns1"}, {"phase", "Complete"}, {"build_config_name", "app"}}
+	rows, err := queryTable(t, server, "openshift_build", []string{"name", "namespace", "phase", "build_config_name"}, quals, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if names := rowNames(rows); !slices.Equal(names, []string{"ns1/app-1"}) {
+		t.Errorf("expected build ns1/app-1, got %v", names)
+	}
+	if len(rows) == 1 && (rows[0]["phase"] != "Complete" || rows[0]["build_config_name"] != "app") {
+		t.Errorf("expected phase Complete and build_config_name app, got %v", rows[0])
+	}
+
+	requests := api.requestsFor("builds")
+	if len(requests) != 1 {
+		t.Fatalf("expected a single list of builds, got %d requests", len(requests))
+	}
+	request := requests[0]
+	if request.namespace != "ns1" {
+		t.Errorf("expected builds to be listed in namespace ns1, got %q", request.namespace)
+	}
+	if selector := request.query.Get("fieldSelector"); !slices.Contains(strings.Split(selector, ","), "status=Complete") {
+		t.Errorf("expected field selector to contain status=Complete, got %q", selector)
+	}
+	if selector := request.query.Get("labelSelector"); selector != "openshift.io/build-config.name=app" {
+		t.Errorf("expected label selector openshift.io/build-config.name=app, got %q", selector)
+	}
+}
+
+func TestIntegrationLimitPushdown(t *testing.T) {
+	objects := []runtime.Object{}
+	for _, route := range testRoutes() {
+		objects = append(objects, route)
+	}
+	api := newFakeAPIServer(t, 0, objects...)
+	server := newTestPluginServer(t, api, "")
+
+	rows, err := queryTable(t, server, "openshift_route", []string{"name", "namespace"}, nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 2 {
+		t.Errorf("expected 2 routes, got %d", len(rows))
+	}
+	requests := api.requestsFor("routes")
+	if len(requests) != 1 || requests[0].query.Get("limit") != "2" {
+		t.Errorf("expected a single list of routes with limit 2, got %v", requests)
+	}
+}
+
+func TestIntegrationGet(t *testing.T) {
+	api := newFakeAPIServer(t, 0,
+		&imagev1.ImageStream{ObjectMeta: testObjectMeta("ns1", "app", nil), Status: imagev1.ImageStreamStatus{DockerImageRepository: "registry.example.com/ns1/app"}},
+	)
+	server := newTestPluginServer(t, api, "")
+	columns := []string{"name", "namespace", "docker_image_repository"}
+
+	rows, err := queryTable(t, server, "openshift_image_stream", columns, []testQual{{"name", "app"}, {"namespace", "ns1"}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0]["docker_image_repository"] != "registry.example.com/ns1/app" {
+		t.Errorf("expected image stream ns1/app, got %v", rows)
+	}
+	requests := api.requestsFor("imagestreams")
+	if len(requests) != 1 || requests[0].name != "app" || requests[0].namespace != "ns1" {
+		t.Errorf("expected a single get of image stream ns1/app, got %v", requests)
+	}
+
+	// not found errors are ignored, returning no rows
+	rows, err = queryTable(t, server, "openshift_image_stream", columns, []testQual{{"name", "missing"}, {"namespace", "ns1"}}, 0)
+	if err != nil {
+		t.Fatalf("expected a missing image stream to be ignored, got %v", err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("expected no rows for a missing image stream, got %v", rows)
+	}
+}
+
+func TestIntegrationGetForbiddenProject(t *testing.T) {
+	api := newFakeAPIServer(t, 0, &projectv1.Project{ObjectMeta: testObjectMeta("", "secret", nil)})
+	api.fail(http.StatusForbidden, 1, func(request fakeRequest) bool {
+		return request.resource == "projects" && request.name == "secret"
+	})
+	server := newTestPluginServer(t, api, "")
+
+	rows, err := queryTable(t, server, "openshift_project", []string{"name", "phase"}, []testQual{{"name", "secret"}}, 0)
+	if err != nil {
+		t.Fatalf("expected a forbidden project to be ignored, got %v", err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("expected no rows for a forbidden project, got %v", rows)
+	}
+}
+
+func TestIntegrationForbiddenMode(t *testing.T) {
+	objects := []runtime.Object{
+		&projectv1.Project{ObjectMeta: testObjectMeta("", "ns1", nil)},
+		&projectv1.Project{ObjectMeta: testObjectMeta("", "ns2", nil)},
+		testBuild("ns1", "app-1", "app", buildv1.BuildPhaseComplete),
+		testBuild("ns2", "app-1", "app", buildv1.BuildPhaseComplete),
+		testBuild("ns3", "app-1", "app", buildv1.BuildPhaseComplete),
+	}
+	// listing builds across all namespaces, or in ns2, is forbidden
+	forbidden := func(request fakeRequest) bool {
+		return request.resource == "builds" && (request.namespace == "" || request.namespace == "ns2")
+	}
+
+	t.Run("error", func(t *testing.T) {
+		api := newFakeAPIServer(t, 0, objects...)
+		api.fail(http.StatusForbidden, 100, forbidden)
+		server := newTestPluginServer(t, api, "")
+
+		_, err := queryTable(t, server, "openshift_build", []string{"name", "namespace"}, nil, 0)
+		if err == nil || !strings.Contains(err.Error(), "fake failure") {
+			t.Errorf("expected the forbidden error, got %v", err)
+		}
+	})
+
+	t.Run("per_project", func(t *testing.T) {
+		api := newFakeAPIServer(t, 0, objects...)
+		api.fail(http.StatusForbidden, 100, forbidden)
+		server := newTestPluginServer(t, api, `forbidden_mode = "per_project"`)
+
+		rows, err := queryTable(t, server, "openshift_build", []string{"name", "namespace"}, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// ns2 is skipped as forbidden, ns3 is not a project the user can access
+		if names := rowNames(rows); !slices.Equal(names, []string{"ns1/app-1"}) {
+			t.Errorf("expected build ns1/app-1, got %v", names)
+		}
+	})
+}
+
+func TestIntegrationConfigContextsWithCredentials(t *testing.T) {
+	api := newFakeAPIServer(t, 0, &userv1.User{ObjectMeta: testObjectMeta("", "alice", nil)})
+	server := newTestPluginServer(t, api, `config_contexts = ["prod-east", "prod-west"]`)
+
+	_, err := queryTable(t, server, "openshift_user", []string{"name"}, nil, 0)
+	if err == nil || !strings.Contains(err.Error(), "config_contexts is only supported with the kubeconfig auth_mode") {
+		t.Errorf("expected config_contexts to be rejected with the credentials auth_mode, got %v", err)
+	}
+}
+
+func TestIntegrationRetryTooManyRequests(t *testing.T) {
+	api := newFakeAPIServer(t, 0,
+		&userv1.User{ObjectMeta: testObjectMeta("", "alice", nil), FullName: "Alice"},
+		&userv1.User{ObjectMeta: testObjectMeta("", "bob", nil), FullName: "Bob"},
+	)
+	api.fail(http.StatusTooManyRequests, 2, func(request fakeRequest) bool {
+		return request.resource == "users"
+	})
+	server := newTestPluginServer(t, api, "")
+
+	rows, err := queryTable(t, server, "openshift_user", []string{"name", "full_name"}, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if names := rowNames(rows); !slices.Equal(names, []string{"alice", "bob"}) {
+		t.Errorf("expected users alice and bob, got %v", names)
+	}
+	if requests := api.requestsFor("users"); len(requests) != 3 {
+		t.Errorf("expected the list to be retried twice, got %d requests", len(requests))
+	}
+}
+
+func TestIntegrationExpiredContinueToken(t *testing.T) {
+	objects := []runtime.Object{}
+	for _, route := range testRoutes() {
+		objects = append(objects, route)
+	}
+	api := newFakeAPIServer(t, 2, objects...)
+	api.fail(http.StatusGone, 1, func(request fakeRequest) bool {
+		return request.resource == "routes" && request.query.Get("continue") == "4"
+	})
+	server := newTestPluginServer(t, api, "")
+
+	rows, err := queryTable(t, server, "openshift_route", []string{"name", "namespace"}, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the list is restarted, without streaming the routes of the first pages twice
+	expected := []string{"ns1/api", "ns1/web", "ns2/admin", "ns2/docs", "ns3/shop"}
+	if names := rowNames(rows); !slices.Equal(names, expected) {
+		t.Errorf("expected routes %v, got %v", expected, names)
+	}
+	if requests := api.requestsFor("routes"); len(requests) != 6 {
+		t.Errorf("expected the list to be restarted after 3 pages, got %d requests", len(requests))
+	}
+}
+
+func TestIntegrationClusterScopedList(t *testing.T) {
+	api := newFakeAPIServer(t, 0,
+		&oauthv1.OAuthAccessToken{ObjectMeta: testObjectMeta("", "sha256~token-1", nil), ClientName: "console", UserName: "alice", Scopes: []string{"user:full"}},
+		&oauthv1.OAuthAccessToken{ObjectMeta: testObjectMeta("", "sha256~token-2", nil), ClientName: "cli", UserName: "bob"},
+	)
+	server := newTestPluginServer(t, api, "")
+
+	rows, err := queryTable(t, server, "openshift_oauth_access_token", []string{"name", "client_name", "user_name", "scopes"}, []testQual{{"user_name", "alice"}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 1 || rows[0]["client_name"] != "console" || rows[0]["scopes"] != `["user:full"]` {
+		t.Errorf("expected the token of alice, got %v", rows)
+	}
+}
+
+func TestIntegrationMetadataOnlyQuery(t *testing.T) {
+	api := newFakeAPIServer(t, 0,
+		&userv1.User{ObjectMeta: testObjectMeta("", "alice", map[string]string{"team": "a"}), FullName: "Alice"},
+	)
+	server := newTestPluginServer(t, api, "")
+
+	rows, err := queryTable(t, server, "openshift_user", []string{"name", "uid", "labels"}, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0]["uid"] != "uid--alice" || rows[0]["labels"] != `{"team":"a"}` {
+		t.Errorf("expected the metadata of alice, got %v", rows)
+	}
+
+	rows, err = queryTable(t, server, "openshift_user", []string{"name", "full_name"}, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0]["full_name"] != "Alice" {
+		t.Errorf("expected the full name of alice, got %v", rows)
+	}
+
+	requests := api.requestsFor("users")
+	if len(requests) != 2 || !requests[0].metadataOnly || requests[1].metadataOnly {
+		t.Errorf("expected only the first query to list the metadata of users, got %v", requests)
+	}
+}
+
+func TestIntegrationSecurityContextConstraint(t *testing.T) {
+	priority := int32(10)
+	api := newFakeAPIServer(t, 0, &securityv1.SecurityContextConstraints{
+		ObjectMeta:               testObjectMeta("", "privileged", nil),
+		Priority:                 &priority,
+		AllowPrivilegedContainer: true,
+		AllowedCapabilities:      []corev1.Capability{"*"},
+		AllowHostNetwork:         true,
+		AllowHostPID:             true,
+		AllowHostIPC:             true,
+		Volumes:                  []securityv1.FSType{securityv1.FSTypeAll},
+		SELinuxContext:           securityv1.SELinuxContextStrategyOptions{Type: securityv1.SELinuxStrategyRunAsAny},
+		RunAsUser:                securityv1.RunAsUserStrategyOptions{Type: securityv1.RunAsUserStrategyRunAsAny},
+		FSGroup:                  securityv1.FSGroupStrategyOptions{Type: securityv1.FSGroupStrategyRunAsAny},
+		SupplementalGroups:       securityv1.SupplementalGroupsStrategyOptions{Type: securityv1.SupplementalGroupsStrategyRunAsAny},
+		Users:                    []string{"system:admin"},
+		Groups:                   []string{"system:cluster-admins"},
+	})
+	server := newTestPluginServer(t, api, "")
+
+	columns := []string{"name", "priority", "allow_privileged_container", "allowed_capabilities", "allow_host_network", "allow_host_pid", "allow_host_ipc",
+		"volumes", "se_linux_context_strategy", "run_as_user_strategy", "fs_group_strategy", "supplemental_groups_strategy", "users", "groups", "title"}
+	rows, err := queryTable(t, server, "openshift_security_context_constraint", columns, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 security context constraint, got %d", len(rows))
+	}
+
+	expected := map[string]interface{}{
+		"name":                         "privileged",
+		"priority":                     int64(10),
+		"allow_privileged_container":   true,
+		"allowed_capabilities":         `["*"]`,
+		"allow_host_network":           true,
+		"allow_host_pid":               true,
+		"allow_host_ipc":               true,
+		"volumes":                      `["*"]`,
+		"se_linux_context_strategy":    "RunAsAny",
+		"run_as_user_strategy":         "RunAsAny",
+		"fs_group_strategy":            "RunAsAny",
+		"supplemental_groups_strategy": "RunAsAny",
+		"users":                        `["system:admin"]`,
+		"groups":                       `["system:cluster-admins"]`,
+		"title":                        "privileged",
+	}
+	for column, value := range expected {
+		if rows[0][column] != value {
+			t.Errorf("expected %s %v, got %v", column, value, rows[0][column])
+		}
+	}
+}
+
+func TestIntegrationClusterOperator(t *testing.T) {
+	condition := func(conditionType configv1.ClusterStatusConditionType, status configv1.ConditionStatus, reason, message string) configv1.ClusterOperatorStatusCondition {
+		return configv1.ClusterOperatorStatusCondition{Type: conditionType, Status: status, Reason: reason, Message: message}
+	}
+	api := newFakeAPIServer(t, 0,
+		&configv1.ClusterOperator{
+			ObjectMeta: testObjectMeta("", "authentication", nil),
+			Status: configv1.ClusterOperatorStatus{
+				Conditions: []configv1.ClusterOperatorStatusCondition{
+					condition(configv1.OperatorAvailable, configv1.ConditionTrue, "AsExpected", ""),
+					condition(configv1.OperatorProgressing, configv1.ConditionFalse, "AsExpected", ""),
+					condition(configv1.OperatorDegraded, configv1.ConditionTrue, "OAuthServerDeploymentDegraded", "1 of 3 replicas are unavailable"),
+				},
+				Versions: []configv1.OperandVersion{{Name: "operator", Version: "4.14.1"}},
+			},
+		},
+		&configv1.ClusterOperator{
+			ObjectMeta: testObjectMeta("", "dns", nil),
+			Status: configv1.ClusterOperatorStatus{
+				Conditions: []configv1.ClusterOperatorStatusCondition{
+					condition(configv1.OperatorAvailable, configv1.ConditionTrue, "AsExpected", ""),
+					condition(configv1.OperatorDegraded, configv1.ConditionFalse, "AsExpected", ""),
+					condition(configv1.OperatorUpgradeable, configv1.ConditionTrue, "AsExpected", ""),
+				},
+			},
+		},
+	)
+	server := newTestPluginServer(t, api, "")
+
+	columns := []string{"name", "available", "progressing", "degraded", "degraded_reason", "degraded_message", "upgradeable", "versions", "related_objects", "extension"}
+	rows, err := queryTable(t, server, "openshift_cluster_operator", columns, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 cluster operators, got %d", len(rows))
+	}
+
+	expected := map[string]map[string]interface{}{
+		"authentication": {
+			"available":        true,
+			"progressing":      false,
+			"degraded":         true,
+			"degraded_reason":  "OAuthServerDeploymentDegraded",
+			"degraded_message": "1 of 3 replicas are unavailable",
+			"upgradeable":      nil,
+			"versions":         `[{"name":"operator","version":"4.14.1"}]`,
+		},
+		"dns": {
+			"available":   true,
+			"progressing": nil,
+			"degraded":    false,
+			"upgradeable": true,
+		},
+	}
+	for _, row := range rows {
+		for column, value := range expected[row["name"].(string)] {
+			if row[column] != value {
+				t.Errorf("expected %s of %s to be %v, got %v", column, row["name"], value, row[column])
+			}
+		}
+	}
+}
+
+func testClusterVersion() *configv1.ClusterVersion {
+	started := v1.NewTime(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+	completed := v1.NewTime(started.Add(45 * time.Minute))
+	return &configv1.ClusterVersion{
+		ObjectMeta: testObjectMeta("", "version", nil),
+		Spec: configv1.ClusterVersionSpec{
+			ClusterID: "7a5d1bbe-0000-4000-8000-000000000001",
+			Channel:   "stable-4.14",
+			Upstream:  "https://api.openshift.com/api/upgrades_info/v1/graph",
+		},
+		Status: configv1.ClusterVersionStatus{
+			Desired: configv1.Release{Version: "4.14.2", Image: "quay.io/openshift-release-dev/ocp-release@sha256:2"},
+			History: []configv1.UpdateHistory{
+				{State: configv1.PartialUpdate, Version: "4.14.2", StartedTime: v1.NewTime(completed.Add(24 * time.Hour))},
+				{State: configv1.CompletedUpdate, Version: "4.14.1", StartedTime: started, CompletionTime: &completed, Verified: true},
+			},
+			Conditions: []configv1.ClusterOperatorStatusCondition{
+				{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+				{Type: configv1.OperatorProgressing, Status: configv1.ConditionTrue, Message: "Working towards 4.14.2: 700 of 860 done (81% complete)"},
+				{Type: clusterVersionFailing, Status: configv1.ConditionFalse},
+			},
+			AvailableUpdates: []configv1.Release{{Version: "4.14.3", Image: "quay.io/openshift-release-dev/ocp-release@sha256:3"}},
+		},
+	}
+}
+
+func TestIntegrationClusterVersion(t *testing.T) {
+	api := newFakeAPIServer(t, 0, testClusterVersion())
+	server := newTestPluginServer(t, api, "")
+
+	columns := []string{"name", "cluster_id", "version", "desired_version", "channel", "upstream", "progressing", "progressing_message",
+		"failing", "upgradeable", "available_updates", "history"}
+	rows, err := queryTable(t, server, "openshift_cluster_version", columns, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 cluster version, got %d", len(rows))
+	}
+
+	expected := map[string]interface{}{
+		"cluster_id":          "7a5d1bbe-0000-4000-8000-000000000001",
+		"version":             "4.14.1",
+		"desired_version":     "4.14.2",
+		"channel":             "stable-4.14",
+		"upstream":            "https://api.openshift.com/api/upgrades_info/v1/graph",
+		"progressing":         true,
+		"progressing_message": "Working towards 4.14.2: 700 of 860 done (81% complete)",
+		"failing":             false,
+		"upgradeable":         nil,
+		"available_updates":   `[{"version":"4.14.3","image":"quay.io/openshift-release-dev/ocp-release@sha256:3"}]`,
+	}
+	for column, value := range expected {
+		if rows[0][column] != value {
+			t.Errorf("expected %s %v, got %v", column, value, rows[0][column])
+		}
+	}
+}
+
+func TestIntegrationClusterVersionHistory(t *testing.T) {
+	api := newFakeAPIServer(t, 0, testClusterVersion())
+	server := newTestPluginServer(t, api, "")
+
+	columns := []string{"cluster_version_name", "cluster_id", "history_index", "state", "version", "started_time", "completion_time", "duration_seconds", "verified", "cluster_server"}
+	rows, err := queryTable(t, server, "openshift_cluster_version_history", columns, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 history entries, got %d", len(rows))
+	}
+	slices.SortFunc(rows, func(a, b map[string]interface{}) int {
+		return int(a["history_index"].(int64) - b["history_index"].(int64))
+	})
+
+	partial, completed := rows[0], rows[1]
+	if partial["state"] != "Partial" || partial["version"] != "4.14.2" || partial["completion_time"] != nil || partial["duration_seconds"] != nil {
+		t.Errorf("expected a partial update to 4.14.2 without completion, got %v", partial)
+	}
+	if completed["state"] != "Completed" || completed["version"] != "4.14.1" || completed["duration_seconds"] != int64(45*60) || completed["verified"] != true {
+		t.Errorf("expected a completed update to 4.14.1 taking 45 minutes, got %v", completed)
+	}
+	if completed["cluster_version_name"] != "version" || completed["cluster_id"] != "7a5d1bbe-0000-4000-8000-000000000001" || completed["cluster_server"] != api.URL {
+		t.Errorf("expected the update to belong to the cluster version of the cluster, got %v", completed)
+	}
+
+	// the query limit applies to the history entries
+	rows, err = queryTable(t, server, "openshift_cluster_version_history", columns, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Errorf("expected 1 history entry, got %d", len(rows))
+	}
+}
+
+func TestIntegrationClusterConfig(t *testing.T) {
+	api := newFakeAPIServer(t, 0,
+		&configv1.APIServer{
+			ObjectMeta: testObjectMeta("", "cluster", nil),
+			Spec: configv1.APIServerSpec{
+				TLSSecurityProfile: &configv1.TLSSecurityProfile{Type: configv1.TLSProfileModernType, Modern: &configv1.ModernTLSProfile{}},
+				Audit:              configv1.Audit{Profile: configv1.WriteRequestBodiesAuditProfileType},
+				Encryption:         configv1.APIServerEncryption{Type: configv1.EncryptionTypeAESGCM},
+			},
+		},
+		&configv1.Infrastructure{
+			ObjectMeta: testObjectMeta("", "cluster", nil),
+			Status: configv1.InfrastructureStatus{
+				InfrastructureName:   "demo-x7k2p",
+				PlatformStatus:       &configv1.PlatformStatus{Type: configv1.AWSPlatformType, AWS: &configv1.AWSPlatformStatus{Region: "us-east-1"}},
+				ControlPlaneTopology: configv1.HighlyAvailableTopologyMode,
+			},
+		},
+		&configv1.OAuth{
+			ObjectMeta: testObjectMeta("", "cluster", nil),
+			Spec: configv1.OAuthSpec{
+				IdentityProviders: []configv1.IdentityProvider{
+					{Name: "local", IdentityProviderConfig: configv1.IdentityProviderConfig{Type: configv1.IdentityProviderTypeHTPasswd}},
+					{Name: "sso", IdentityProviderConfig: configv1.IdentityProviderConfig{Type: configv1.IdentityProviderTypeOpenID}},
+				},
+				TokenConfig: configv1.TokenConfig{AccessTokenInactivityTimeout: &v1.Duration{Duration: 5 * time.Minute}},
+			},
+		},
+		&configv1.Image{
+			ObjectMeta: testObjectMeta("", "cluster", nil),
+			Spec: configv1.ImageSpec{
+				RegistrySources: configv1.RegistrySources{AllowedRegistries: []string{"quay.io", "registry.redhat.io"}},
+			},
+		},
+	)
+	server := newTestPluginServer(t, api, "")
+
+	tests := []struct {
+		table    string
+		expected map[string]interface{}
+	}{
+		{
+			table: "openshift_config_api_server",
+			expected: map[string]interface{}{
+				"tls_security_profile_type": "Modern",
+				"audit_profile":             "WriteRequestBodies",
+				"encryption_type":           "aesgcm",
+				"client_ca":                 nil,
+			},
+		},
+		{
+			table: "openshift_config_infrastructure",
+			expected: map[string]interface{}{
+				"infrastructure_name":    "demo-x7k2p",
+				"platform_type":          "AWS",
+				"control_plane_topology": "HighlyAvailable",
+			},
+		},
+		{
+			table: "openshift_config_oauth",
+			expected: map[string]interface{}{
+				"identity_provider_types":         `["HTPasswd","OpenID"]`,
+				"access_token_inactivity_timeout": "5m0s",
+				"access_token_max_age_seconds":    nil,
+			},
+		},
+		{
+			table: "openshift_config_image",
+			expected: map[string]interface{}{
+				"allowed_registries": `["quay.io","registry.redhat.io"]`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.table, func(t *testing.T) {
+			columns := []string{"name"}
+			for column := range test.expected {
+				columns = append(columns, column)
+			}
+			rows, err := queryTable(t, server, test.table, columns, nil, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rows) != 1 || rows[0]["name"] != "cluster" {
+				t.Fatalf("expected the cluster config, got %v", rows)
+			}
+			for column, value := range test.expected {
+				if rows[0][column] != value {
+					t.Errorf("expected %s %v, got %v", column, value, rows[0][column])
+				}
+			}
+		})
+	}
+}
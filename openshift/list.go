@@ -0,0 +1,331 @@
+package openshift
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"golang.org/x/sync/errgroup"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Supported values of the forbidden_mode connection config argument
+const (
+	forbiddenModeError      = "error"
+	forbiddenModePerProject = "per_project"
+)
+
+// Supported values of the list_consistency connection config argument
+const (
+	// listConsistencyQuorum lists the latest state of the resource from etcd
+	listConsistencyQuorum = "quorum"
+	// listConsistencyCached lists the resource from the API server watch cache, which may be slightly stale
+	listConsistencyCached = "cached"
+)
+
+// listPageFunc lists a single page of a resource in the namespace, or across all namespaces if empty.
+// Cluster scoped resources ignore the namespace.
+type listPageFunc func(ctx context.Context, namespace string, input v1.ListOptions) (runtime.Object, error)
+
+// maxNamespaceListConcurrency limits the number of namespaces listed at the same time
+const maxNamespaceListConcurrency = 10
+
+// maxExpiredContinueRetries limits the number of times a list is resumed or restarted after its continue token expired
+const maxExpiredContinueRetries = 3
+
+// listResource :: pages through a cluster scoped resource, streaming each item
+func listResource(ctx context.Context, d *plugin.QueryData, clients *openshiftClients, input v1.ListOptions, source resourceSource) error {
+	streamer := newItemStreamer(ctx, d)
+	if served, err := listFromInformer(ctx, d, streamer, source, input, nil); served {
+		return err
+	}
+
+	_, err := listPages(ctx, streamer, "", input, withMetadataFastPath(ctx, d, clients, source.resource, source.list))
+	return err
+}
+
+// listProjectResource :: pages through projects, streaming each project which matches the namespace filters of the connection
+func listProjectResource(ctx context.Context, d *plugin.QueryData, clients *openshiftClients, input v1.ListOptions, source resourceSource) error {
+	// projects are namespaces, so the namespace filters apply to their names
+	filter := getNamespaceFilter(d)
+	streamer := newItemStreamer(ctx, d)
+	streamer.include = func(item runtime.Object) bool {
+		object, err := meta.Accessor(item)
+		return err == nil && filter.matches(object.GetName())
+	}
+
+	if served, err := listFromInformer(ctx, d, streamer, source, input, nil); served {
+		return err
+	}
+
+	_, err := listPages(ctx, streamer, "", input, withMetadataFastPath(ctx, d, clients, source.resource, source.list))
+	return err
+}
+
+// listNamespacedResource :: pages through a namespaced resource, streaming each item.
+// If the query has namespace quals, e.g. namespace in ('a', 'b'), the resource is listed in each of the namespaces.
+// Otherwise it is listed across all namespaces and, if that is forbidden and the forbidden_mode is per_project,
+// in each project the user has access to instead.
+func listNamespacedResource(ctx context.Context, d *plugin.QueryData, clients *openshiftClients, input v1.ListOptions, source resourceSource) error {
+	filter := getNamespaceFilter(d)
+	streamer := newItemStreamer(ctx, d)
+	streamer.include = func(item runtime.Object) bool {
+		object, err := meta.Accessor(item)
+		return err == nil && filter.matches(object.GetNamespace())
+	}
+
+	namespaces := getNamespaceQualValues(d)
+	if served, err := listFromInformer(ctx, d, streamer, source, input, namespaces); served {
+		return err
+	}
+
+	listPage := withMetadataFastPath(ctx, d, clients, source.resource, source.list)
+	perProject := getForbiddenMode(GetConfig(d.Connection)) == forbiddenModePerProject
+
+	if len(namespaces) > 0 {
+		return listNamespaces(ctx, streamer, filter.filter(namespaces), input, listPage, perProject)
+	}
+
+	_, err := listPages(ctx, streamer, "", input, listPage)
+	if err == nil || !apierrors.IsForbidden(err) || !perProject {
+		return err
+	}
+	plugin.Logger(ctx).Warn("listNamespacedResource", "cluster_wide_list_forbidden", err, "fallback", forbiddenModePerProject)
+
+	projects, err := listAccessibleProjects(ctx, clients)
+	if err != nil {
+		return err
+	}
+
+	return listNamespaces(ctx, streamer, filter.filter(projects), input, listPage, true)
+}
+
+// listNamespaces :: lists the resource in each of the namespaces concurrently, merging the items into a single stream.
+// Namespaces where listing is forbidden are skipped and logged if skipForbidden is set.
+func listNamespaces(ctx context.Context, streamer *itemStreamer, namespaces []string, input v1.ListOptions, listPage listPageFunc, skipForbidden bool) error {
+	var lock sync.Mutex
+	skipped := []string{}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(maxNamespaceListConcurrency)
+	for _, namespace := range namespaces {
+		group.Go(func() error {
+			// no need to list further namespaces once the query has all the rows it needs
+			if streamer.complete() {
+				return nil
+			}
+			_, err := listPages(groupCtx, streamer, namespace, input, listPage)
+			if err != nil && skipForbidden && apierrors.IsForbidden(err) {
+				lock.Lock()
+				skipped = append(skipped, namespace)
+				lock.Unlock()
+				return nil
+			}
+			return err
+		})
+	}
+	err := group.Wait()
+
+	if len(skipped) > 0 {
+		slices.Sort(skipped)
+		plugin.Logger(ctx).Warn("listNamespaces", "skipped_namespaces", skipped)
+	}
+
+	return err
+}
+
+// listPages :: pages through the resource in the namespace, streaming each item.
+// Returns false once no more rows are required by the query.
+func listPages(ctx context.Context, streamer *itemStreamer, namespace string, input v1.ListOptions, listPage listPageFunc) (bool, error) {
+	// a resource version of 0 serves the first page from the watch cache rather than etcd
+	cached := streamer.cached
+	if cached {
+		input.ResourceVersion = "0"
+	}
+
+	// the last listed item, to skip the items already listed if the list is restarted after its continue token expired.
+	// Lists are ordered by the etcd keys of the items, i.e. by namespace/name, so no other items need to be tracked.
+	var lastListed v1.Object
+	resumeAfter := ""
+	expiredRetries := 0
+
+	for {
+		response, err := listPage(ctx, namespace, input)
+		if err != nil && cached && shouldFallbackToQuorum(err) {
+			plugin.Logger(ctx).Warn("listPages", "cached_list_error", err, "namespace", namespace, "fallback", listConsistencyQuorum)
+			cached = false
+			input.ResourceVersion = ""
+			response, err = listPage(ctx, namespace, input)
+		}
+		if err != nil && input.Continue != "" && isExpiredError(err) && expiredRetries < maxExpiredContinueRetries {
+			// resume from the inconsistent continue token the API server returns with the error, if any,
+			// otherwise restart the list from the first page
+			expiredRetries++
+			input.Continue = getInconsistentContinueToken(err)
+			if lastListed != nil {
+				resumeAfter = listKey(lastListed)
+			}
+			plugin.Logger(ctx).Warn("listPages", "continue_token_expired", err, "namespace", namespace, "resume", input.Continue != "", "retry", expiredRetries)
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		items, err := meta.ExtractList(response)
+		if err != nil {
+			return false, err
+		}
+		for _, item := range items {
+			object, err := meta.Accessor(item)
+			if err != nil {
+				return false, err
+			}
+			if resumeAfter != "" {
+				if listKey(object) <= resumeAfter {
+					continue
+				}
+				resumeAfter = ""
+			}
+			lastListed = object
+			if !streamer.stream(item) {
+				return false, nil
+			}
+		}
+
+		listMeta, err := meta.ListAccessor(response)
+		if err != nil {
+			return false, err
+		}
+		if listMeta.GetContinue() == "" {
+			break
+		}
+		// the resource version must not be set together with a continue token, which already pins the list to a snapshot
+		input.Continue = listMeta.GetContinue()
+		input.ResourceVersion = ""
+	}
+
+	return true, nil
+}
+
+// listKey :: returns the key lists are ordered by, the same as the suffix of the etcd key of the object
+func listKey(object v1.Object) string {
+	return object.GetNamespace() + "/" + object.GetName()
+}
+
+// itemStreamer streams list items to the query. It is safe for concurrent use when listing several namespaces.
+type itemStreamer struct {
+	lock sync.Mutex
+	// include optionally filters the items to stream
+	include func(item runtime.Object) bool
+	// cached lists the first page of each list from the API server watch cache, see list_consistency
+	cached bool
+	// streamItem streams an item to the query, and rowsRemaining returns the number of rows the query still needs
+	streamItem    func(item interface{})
+	rowsRemaining func() int64
+}
+
+func newItemStreamer(ctx context.Context, d *plugin.QueryData) *itemStreamer {
+	return &itemStreamer{
+		cached: getListConsistency(GetConfig(d.Connection)) == listConsistencyCached,
+		streamItem: func(item interface{}) {
+			d.StreamListItem(ctx, item)
+		},
+		rowsRemaining: func() int64 {
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			return d.RowsRemaining(ctx)
+		},
+	}
+}
+
+// stream :: streams the item, returns false once no more rows are required by the query
+func (s *itemStreamer) stream(item runtime.Object) bool {
+	if s.include != nil && !s.include(item) {
+		return true
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.rowsRemaining() == 0 {
+		return false
+	}
+	s.streamItem(item)
+	return s.rowsRemaining() != 0
+}
+
+// complete :: checks whether the query has all the rows it needs
+func (s *itemStreamer) complete() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.rowsRemaining() == 0
+}
+
+// getNamespaceQualValues :: returns the namespaces of the equal quals of the namespace column, including IN lists
+func getNamespaceQualValues(d *plugin.QueryData) []string {
+	namespaces := []string{}
+	qual := d.EqualsQuals["namespace"]
+	if qual == nil {
+		return namespaces
+	}
+
+	if listValue := qual.GetListValue(); listValue != nil {
+		for _, value := range listValue.Values {
+			if namespace := value.GetStringValue(); namespace != "" && !slices.Contains(namespaces, namespace) {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+	} else if namespace := qual.GetStringValue(); namespace != "" {
+		namespaces = append(namespaces, namespace)
+	}
+
+	return namespaces
+}
+
+// listAccessibleProjects :: returns the names of the projects the user has access to.
+// Unlike namespaces, the projects API only returns the projects the user can see.
+func listAccessibleProjects(ctx context.Context, clients *openshiftClients) ([]string, error) {
+	projects := []string{}
+	input := v1.ListOptions{Limit: 1000}
+	for {
+		response, err := clients.Project.Projects().List(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range response.Items {
+			projects = append(projects, project.Name)
+		}
+		if response.Continue == "" {
+			break
+		}
+		input.Continue = response.Continue
+	}
+
+	return projects, nil
+}
+
+// getForbiddenMode :: resolves the forbidden_mode connection config argument, defaults to error
+func getForbiddenMode(openshiftConfig openshiftConfig) string {
+	if openshiftConfig.ForbiddenMode != nil {
+		return *openshiftConfig.ForbiddenMode
+	}
+	return forbiddenModeError
+}
+
+// getListConsistency :: resolves the list_consistency connection config argument, defaults to quorum
+func getListConsistency(openshiftConfig openshiftConfig) string {
+	if openshiftConfig.ListConsistency != nil {
+		return *openshiftConfig.ListConsistency
+	}
+	return listConsistencyQuorum
+}
+
+// shouldFallbackToQuorum :: checks whether a failed cached list is worth retrying as a quorum read.
+// Authorization errors and missing resources fail the same way from etcd, so they are not retried.
+func shouldFallbackToQuorum(err error) bool {
+	return !apierrors.IsForbidden(err) && !apierrors.IsUnauthorized(err) && !apierrors.IsNotFound(err)
+}
@@ -0,0 +1,279 @@
+package openshift
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	buildv1 "github.com/openshift/api/build/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// pagedBuilds :: returns a listPageFunc serving the builds in pages, keyed by the continue token of the request
+func pagedBuilds(pages map[string]*buildv1.BuildList, requests *[]v1.ListOptions) listPageFunc {
+	return func(_ context.Context, _ string, input v1.ListOptions) (runtime.Object, error) {
+		*requests = append(*requests, input)
+		page, ok := pages[input.Continue]
+		if !ok {
+			return nil, errors.New("unexpected continue token " + input.Continue)
+		}
+		return page, nil
+	}
+}
+
+func newBuildList(continueToken string, names ...string) *buildv1.BuildList {
+	list := &buildv1.BuildList{ListMeta: v1.ListMeta{Continue: continueToken}}
+	for _, name := range names {
+		list.Items = append(list.Items, buildv1.Build{ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "ns", UID: types.UID("uid-" + name)}})
+	}
+	return list
+}
+
+func TestListPagesFollowsContinueTokens(t *testing.T) {
+	requests := []v1.ListOptions{}
+	listPage := pagedBuilds(map[string]*buildv1.BuildList{
+		"":       newBuildList("page-2", "b1", "b2"),
+		"page-2": newBuildList("page-3", "b3"),
+		"page-3": newBuildList("", "b4"),
+	}, &requests)
+
+	streamer := newTestStreamer(0)
+	more, err := listPages(newTestContext(), streamer.itemStreamer, "", v1.ListOptions{Limit: 2}, listPage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !more {
+		t.Error("listPages returned false, expected the query to need more rows")
+	}
+	if got, want := streamer.names(t), []string{"b1", "b2", "b3", "b4"}; !slices.Equal(got, want) {
+		t.Errorf("streamed %v, want %v", got, want)
+	}
+	for i, request := range requests {
+		if request.Limit != 2 {
+			t.Errorf("request %d has limit %d, want 2", i, request.Limit)
+		}
+	}
+}
+
+func TestListPagesStopsAtQueryLimit(t *testing.T) {
+	requests := []v1.ListOptions{}
+	listPage := pagedBuilds(map[string]*buildv1.BuildList{
+		"":       newBuildList("page-2", "b1", "b2"),
+		"page-2": newBuildList("", "b3"),
+	}, &requests)
+
+	streamer := newTestStreamer(2)
+	more, err := listPages(newTestContext(), streamer.itemStreamer, "", v1.ListOptions{Limit: 2}, listPage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if more {
+		t.Error("listPages returned true, expected the query to be complete")
+	}
+	if got, want := streamer.names(t), []string{"b1", "b2"}; !slices.Equal(got, want) {
+		t.Errorf("streamed %v, want %v", got, want)
+	}
+	if len(requests) != 1 {
+		t.Errorf("listed %d pages, want 1", len(requests))
+	}
+}
+
+func TestListPagesHandlesExpiredContinueToken(t *testing.T) {
+	expired := apierrors.NewResourceExpired("the provided continue parameter is too old")
+	expiredWithToken := apierrors.NewResourceExpired("the provided continue parameter is too old")
+	expiredWithToken.ErrStatus.ListMeta.Continue = "inconsistent"
+
+	tests := []struct {
+		name  string
+		err   *apierrors.StatusError
+		pages map[string]*buildv1.BuildList
+		want  []string
+	}{
+		{
+			name: "resumes from the inconsistent continue token",
+			err:  expiredWithToken,
+			pages: map[string]*buildv1.BuildList{
+				"":             newBuildList("page-2", "b1", "b2"),
+				"inconsistent": newBuildList("", "b3"),
+			},
+			want: []string{"b1", "b2", "b3"},
+		},
+		{
+			name: "restarts the list and skips streamed items",
+			err:  expired,
+			pages: map[string]*buildv1.BuildList{
+				"": newBuildList("page-2", "b1", "b2"),
+			},
+			want: []string{"b1", "b2", "b3"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests := []v1.ListOptions{}
+			restarted := false
+			listPage := func(ctx context.Context, namespace string, input v1.ListOptions) (runtime.Object, error) {
+				if input.Continue == "page-2" {
+					requests = append(requests, input)
+					return nil, test.err
+				}
+				// the restarted list returns the first page again, along with a new build
+				if input.Continue == "" && len(requests) > 0 {
+					restarted = true
+					return newBuildList("", "b1", "b2", "b3"), nil
+				}
+				return pagedBuilds(test.pages, &requests)(ctx, namespace, input)
+			}
+
+			streamer := newTestStreamer(0)
+			if _, err := listPages(newTestContext(), streamer.itemStreamer, "", v1.ListOptions{Limit: 2}, listPage); err != nil {
+				t.Fatal(err)
+			}
+			if got := streamer.names(t); !slices.Equal(got, test.want) {
+				t.Errorf("streamed %v, want %v", got, test.want)
+			}
+			if wantRestart := test.err.ErrStatus.ListMeta.Continue == ""; restarted != wantRestart {
+				t.Errorf("restarted = %v, want %v", restarted, wantRestart)
+			}
+		})
+	}
+}
+
+func TestListPagesGivesUpOnRepeatedlyExpiredContinueToken(t *testing.T) {
+	listPage := func(_ context.Context, _ string, input v1.ListOptions) (runtime.Object, error) {
+		if input.Continue == "" {
+			return newBuildList("page-2", "b1"), nil
+		}
+		return nil, apierrors.NewResourceExpired("the provided continue parameter is too old")
+	}
+
+	streamer := newTestStreamer(0)
+	_, err := listPages(newTestContext(), streamer.itemStreamer, "", v1.ListOptions{}, listPage)
+	if !apierrors.IsResourceExpired(err) {
+		t.Errorf("listPages returned %v, want a resource expired error", err)
+	}
+	if got, want := streamer.names(t), []string{"b1"}; !slices.Equal(got, want) {
+		t.Errorf("streamed %v, want %v", got, want)
+	}
+}
+
+func TestListPagesCachedConsistency(t *testing.T) {
+	tests := []struct {
+		name     string
+		cacheErr error
+		want     []string
+		wantErr  bool
+	}{
+		{name: "served from the watch cache", want: []string{"0", "", ""}},
+		{name: "falls back to quorum", cacheErr: apierrors.NewInternalError(errors.New("watch cache unavailable")), want: []string{"0", "", "", ""}},
+		{name: "does not fall back when forbidden", cacheErr: apierrors.NewForbidden(buildv1.Resource("builds"), "", errors.New("denied")), want: []string{"0"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resourceVersions := []string{}
+			pages := map[string]*buildv1.BuildList{
+				"":       newBuildList("page-2", "b1"),
+				"page-2": newBuildList("page-3", "b2"),
+				"page-3": newBuildList("", "b3"),
+			}
+			listPage := func(_ context.Context, _ string, input v1.ListOptions) (runtime.Object, error) {
+				resourceVersions = append(resourceVersions, input.ResourceVersion)
+				if input.ResourceVersion == "0" && input.Continue != "" {
+					return nil, apierrors.NewBadRequest("specifying resource version is not allowed when using continue")
+				}
+				if input.ResourceVersion == "0" && test.cacheErr != nil {
+					return nil, test.cacheErr
+				}
+				return pages[input.Continue], nil
+			}
+
+			streamer := newTestStreamer(0)
+			streamer.cached = true
+			_, err := listPages(newTestContext(), streamer.itemStreamer, "", v1.ListOptions{}, listPage)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("listPages returned %v, want error %v", err, test.wantErr)
+			}
+			if !slices.Equal(resourceVersions, test.want) {
+				t.Errorf("listed with resource versions %q, want %q", resourceVersions, test.want)
+			}
+		})
+	}
+}
+
+func TestListNamespaces(t *testing.T) {
+	clients, fakes := newFakeClients(t,
+		&buildv1.Build{ObjectMeta: v1.ObjectMeta{Name: "b1", Namespace: "team-a"}},
+		&buildv1.Build{ObjectMeta: v1.ObjectMeta{Name: "b2", Namespace: "team-b"}},
+		&buildv1.Build{ObjectMeta: v1.ObjectMeta{Name: "b3", Namespace: "restricted"}},
+		&buildv1.Build{ObjectMeta: v1.ObjectMeta{Name: "b4", Namespace: "other"}},
+	)
+	fakes.build.PrependReactor("list", "builds", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == "restricted" {
+			return true, nil, apierrors.NewForbidden(buildv1.Resource("builds"), "", errors.New("denied"))
+		}
+		return false, nil, nil
+	})
+	source := buildResource.source(clients)
+	namespaces := []string{"team-a", "team-b", "restricted"}
+
+	t.Run("skips forbidden namespaces", func(t *testing.T) {
+		streamer := newTestStreamer(0)
+		if err := listNamespaces(newTestContext(), streamer.itemStreamer, namespaces, v1.ListOptions{}, source.list, true); err != nil {
+			t.Fatal(err)
+		}
+		got := streamer.names(t)
+		slices.Sort(got)
+		if want := []string{"b1", "b2"}; !slices.Equal(got, want) {
+			t.Errorf("streamed %v, want %v", got, want)
+		}
+	})
+
+	t.Run("fails on forbidden namespaces", func(t *testing.T) {
+		streamer := newTestStreamer(0)
+		err := listNamespaces(newTestContext(), streamer.itemStreamer, namespaces, v1.ListOptions{}, source.list, false)
+		if !apierrors.IsForbidden(err) {
+			t.Errorf("listNamespaces returned %v, want a forbidden error", err)
+		}
+	})
+
+	t.Run("filters namespaces", func(t *testing.T) {
+		filter := namespaceFilter{include: []string{"team-*"}, exclude: []string{"team-b"}}
+		streamer := newTestStreamer(0)
+		streamer.include = func(item runtime.Object) bool {
+			return filter.matches(item.(*buildv1.Build).Namespace)
+		}
+		if _, err := listPages(newTestContext(), streamer.itemStreamer, "", v1.ListOptions{}, source.list); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := streamer.names(t), []string{"b1"}; !slices.Equal(got, want) {
+			t.Errorf("streamed %v, want %v", got, want)
+		}
+	})
+}
+
+func TestGetNamespaceQualValues(t *testing.T) {
+	tests := []struct {
+		name string
+		d    *plugin.QueryData
+		want []string
+	}{
+		{name: "no qual", d: newTestQueryData(openshiftConfig{}, nil), want: []string{}},
+		{name: "single namespace", d: newTestQueryData(openshiftConfig{}, nil, newStringQual("namespace", "=", "a")), want: []string{"a"}},
+		{name: "in list", d: newTestQueryData(openshiftConfig{}, nil, newStringListQual("namespace", "a", "b", "a", "")), want: []string{"a", "b"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getNamespaceQualValues(test.d); !slices.Equal(got, test.want) {
+				t.Errorf("getNamespaceQualValues() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package openshift
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// withMetadataFastPath :: returns a listPageFunc which lists only the object metadata of the resource, as a
+// PartialObjectMetadataList, if the query only requests metadata columns. Otherwise returns the typed listPage.
+// This avoids downloading large specs and statuses, e.g. pod templates and image stream tag histories, for inventory queries.
+func withMetadataFastPath(ctx context.Context, d *plugin.QueryData, clients *openshiftClients, resource schema.GroupVersionResource, listPage listPageFunc) listPageFunc {
+	if !isMetadataOnlyQuery(d) {
+		return listPage
+	}
+	plugin.Logger(ctx).Debug("withMetadataFastPath", "resource", resource.String(), "columns", d.QueryContext.Columns)
+
+	return func(ctx context.Context, namespace string, input v1.ListOptions) (runtime.Object, error) {
+		return clients.Metadata.Resource(resource).Namespace(namespace).List(ctx, input)
+	}
+}
+
+// isMetadataOnlyQuery :: checks whether all the columns requested by the query can be populated from the object metadata
+func isMetadataOnlyQuery(d *plugin.QueryData) bool {
+	metadataColumns := map[string]bool{
+		// every table populates its title from the object name
+		"title": true,
+	}
+	for _, column := range append(objectMetadataColumns(), clusterColumns()...) {
+		metadataColumns[column.Name] = true
+	}
+
+	for _, column := range d.QueryContext.Columns {
+		if !metadataColumns[column] && !plugin.IsReservedColumnName(column) {
+			return false
+		}
+	}
+	return true
+}
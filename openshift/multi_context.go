@@ -0,0 +1,100 @@
+package openshift
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"k8s.io/client-go/rest"
+)
+
+// Matrix item keys, which are also the names of the cluster columns
+const (
+	matrixKeyContextName   = "context_name"
+	matrixKeyClusterName   = "cluster_name"
+	matrixKeyClusterServer = "cluster_server"
+)
+
+// BuildContextList :: return a list of matrix items, one per kubeconfig context queried by the connection
+func BuildContextList(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
+	openshiftConfig := GetConfig(d.Connection)
+
+	matrix, err := getContextMatrix(openshiftConfig)
+	if err != nil {
+		// the error is returned by the client when the table is queried
+		plugin.Logger(ctx).Error("BuildContextList", "error", err)
+		return []map[string]interface{}{newContextMatrixItem("", "", "")}
+	}
+
+	return matrix
+}
+
+// getContextMatrix :: builds the matrix items for the auth mode of the connection
+func getContextMatrix(openshiftConfig openshiftConfig) ([]map[string]interface{}, error) {
+	authMode, err := getAuthMode(openshiftConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	switch authMode {
+	case authModeCredentials:
+		return []map[string]interface{}{newContextMatrixItem("", "", getConfigValue(openshiftConfig.Host, "OPENSHIFT_HOST"))}, nil
+	case authModeInCluster:
+		server := ""
+		if restconfig, err := rest.InClusterConfig(); err == nil {
+			server = restconfig.Host
+		}
+		return []map[string]interface{}{newContextMatrixItem("", "", server)}, nil
+	}
+
+	loader, _, err := getKubeconfigLoadingRules(openshiftConfig)
+	if err != nil {
+		return nil, err
+	}
+	rawConfig, err := loader.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	// config_contexts takes precedence over config_context and the current context
+	contexts := openshiftConfig.ConfigContexts
+	if len(contexts) == 0 {
+		contextName := rawConfig.CurrentContext
+		if openshiftConfig.ConfigContext != nil {
+			contextName = *openshiftConfig.ConfigContext
+		}
+		contexts = []string{contextName}
+	}
+
+	matrix := make([]map[string]interface{}, 0, len(contexts))
+	for _, contextName := range contexts {
+		clusterName, server := "", ""
+		// unknown contexts are still added, the client reports them as not found
+		if kubeContext, ok := rawConfig.Contexts[contextName]; ok {
+			clusterName = kubeContext.Cluster
+			if cluster, ok := rawConfig.Clusters[clusterName]; ok {
+				server = cluster.Server
+			}
+		}
+		matrix = append(matrix, newContextMatrixItem(contextName, clusterName, server))
+	}
+
+	return matrix, nil
+}
+
+func newContextMatrixItem(contextName, clusterName, server string) map[string]interface{} {
+	return map[string]interface{}{
+		matrixKeyContextName:   contextName,
+		matrixKeyClusterName:   clusterName,
+		matrixKeyClusterServer: server,
+	}
+}
+
+// getMatrixContextName :: returns the kubeconfig context of the matrix item being queried, empty if not set
+func getMatrixContextName(ctx context.Context) string {
+	matrixItem := plugin.GetMatrixItem(ctx)
+	if matrixItem == nil {
+		return ""
+	}
+	contextName, _ := matrixItem[matrixKeyContextName].(string)
+	return contextName
+}
@@ -0,0 +1,64 @@
+package openshift
+
+import (
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// namespaceFilter matches namespaces against the glob patterns of the namespaces and
+// exclude_namespaces connection config arguments, e.g. "tenant-*" or "openshift-*"
+type namespaceFilter struct {
+	include []string
+	exclude []string
+}
+
+func getNamespaceFilter(d *plugin.QueryData) namespaceFilter {
+	openshiftConfig := GetConfig(d.Connection)
+	return namespaceFilter{
+		include: openshiftConfig.Namespaces,
+		exclude: openshiftConfig.ExcludeNamespaces,
+	}
+}
+
+// matches :: checks the namespace matches any of the included patterns, if set, and none of the excluded patterns
+func (f namespaceFilter) matches(namespace string) bool {
+	if len(f.include) > 0 && !matchesAnyPattern(f.include, namespace) {
+		return false
+	}
+	return !matchesAnyPattern(f.exclude, namespace)
+}
+
+// filter :: returns the namespaces which match the filter
+func (f namespaceFilter) filter(namespaces []string) []string {
+	return slices.DeleteFunc(slices.Clone(namespaces), func(namespace string) bool {
+		return !f.matches(namespace)
+	})
+}
+
+// validate :: checks all the patterns are valid globs
+func (f namespaceFilter) validate() error {
+	for _, pattern := range append(slices.Clone(f.include), f.exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid namespace pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+func matchesAnyPattern(patterns []string, namespace string) bool {
+	for _, pattern := range patterns {
+		// invalid patterns are rejected when the client is created
+		if matched, _ := path.Match(pattern, namespace); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// isNamespaceAllowed :: checks the namespace is not filtered out by the connection config, for use in get calls
+func isNamespaceAllowed(d *plugin.QueryData, namespace string) bool {
+	return getNamespaceFilter(d).matches(namespace)
+}
@@ -12,22 +12,38 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 		Name:             "steampipe-plugin-openshift",
 		DefaultTransform: transform.FromCamel(),
 		DefaultIgnoreConfig: &plugin.IgnoreConfig{
-			ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"404"}),
+			ShouldIgnoreErrorFunc: shouldIgnoreErrors(defaultIgnoreErrors...),
 		},
 		DefaultRetryConfig: &plugin.RetryConfig{
-			ShouldRetryErrorFunc: shouldRetryError([]string{"429"})},
+			ShouldRetryErrorFunc: shouldRetryError(defaultRetryErrors...)},
 		ConnectionConfigSchema: &plugin.ConnectionConfigSchema{
 			NewInstance: ConfigInstance,
 		},
 		TableMap: map[string]*plugin.Table{
-			"openshift_build_config":       tableOpenShiftBuildConfig(ctx),
-			"openshift_build":              tableOpenShiftBuild(ctx),
-			"openshift_deployment_config":  tableOpenShiftDeploymentConfig(ctx),
-			"openshift_image_stream":       tableOpenShiftImageStream(ctx),
-			"openshift_oauth_access_token": tableOpenShiftOAuthAccessToken(ctx),
-			"openshift_project":            tableOpenShiftProject(ctx),
-			"openshift_route":              tableOpenShiftRoute(ctx),
-			"openshift_user":               tableOpenShiftUser(ctx),
+			"openshift_build_config":                tableOpenShiftBuildConfig(ctx),
+			"openshift_build":                       tableOpenShiftBuild(ctx),
+			"openshift_cluster_operator":            tableOpenShiftClusterOperator(ctx),
+			"openshift_cluster_version":             tableOpenShiftClusterVersion(ctx),
+			"openshift_cluster_version_history":     tableOpenShiftClusterVersionHistory(ctx),
+			"openshift_config_api_server":           tableOpenShiftConfigAPIServer(ctx),
+			"openshift_config_authentication":       tableOpenShiftConfigAuthentication(ctx),
+			"openshift_config_console":              tableOpenShiftConfigConsole(ctx),
+			"openshift_config_dns":                  tableOpenShiftConfigDNS(ctx),
+			"openshift_config_feature_gate":         tableOpenShiftConfigFeatureGate(ctx),
+			"openshift_config_image":                tableOpenShiftConfigImage(ctx),
+			"openshift_config_infrastructure":       tableOpenShiftConfigInfrastructure(ctx),
+			"openshift_config_ingress":              tableOpenShiftConfigIngress(ctx),
+			"openshift_config_network":              tableOpenShiftConfigNetwork(ctx),
+			"openshift_config_oauth":                tableOpenShiftConfigOAuth(ctx),
+			"openshift_config_proxy":                tableOpenShiftConfigProxy(ctx),
+			"openshift_config_scheduler":            tableOpenShiftConfigScheduler(ctx),
+			"openshift_deployment_config":           tableOpenShiftDeploymentConfig(ctx),
+			"openshift_image_stream":                tableOpenShiftImageStream(ctx),
+			"openshift_oauth_access_token":          tableOpenShiftOAuthAccessToken(ctx),
+			"openshift_project":                     tableOpenShiftProject(ctx),
+			"openshift_route":                       tableOpenShiftRoute(ctx),
+			"openshift_security_context_constraint": tableOpenShiftSecurityContextConstraint(ctx),
+			"openshift_user":                        tableOpenShiftUser(ctx),
 		},
 	}
 	return p
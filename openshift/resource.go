@@ -0,0 +1,276 @@
+package openshift
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// resourceScope is how a resource is scoped, which determines its key columns and how it is listed
+type resourceScope int
+
+const (
+	// namespacedScope resources, e.g. builds, are listed across all namespaces or in the namespaces of the quals
+	namespacedScope resourceScope = iota
+	// clusterScope resources, e.g. users, are listed across the cluster
+	clusterScope
+	// projectScope is for projects, which are cluster scoped but filtered by the namespace filters of the connection
+	projectScope
+)
+
+// typedClient is implemented by the typed client of every OpenShift resource, e.g. build_v1.BuildInterface,
+// where T is the object type, e.g. *buildv1.Build, and L the list type, e.g. *buildv1.BuildList.
+type typedClient[T runtime.Object, L runtime.Object] interface {
+	List(ctx context.Context, opts v1.ListOptions) (L, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Get(ctx context.Context, name string, opts v1.GetOptions) (T, error)
+}
+
+// resourceTable is the declarative definition of the resource served by a table. It provides the list and get
+// configs of the table, with consistent paging, limits, qualifiers, logging and error handling.
+type resourceTable[T runtime.Object, L runtime.Object] struct {
+	// name of the table, used in log keys, e.g. openshift_build
+	name     string
+	resource schema.GroupVersionResource
+	scope    resourceScope
+	// selectorQuals are the columns, other than name and namespace, the API server can filter the resource by
+	selectorQuals []selectorQual
+	// ignoreGetErrors overrides the errors ignored by get calls, if set
+	ignoreGetErrors []errorPredicate
+	// ignoreListErrors overrides the errors ignored by list calls, if set
+	ignoreListErrors []errorPredicate
+	// retryErrors overrides the errors list and get calls are retried on, if set
+	retryErrors []errorPredicate
+	// client returns the typed client of the resource in the namespace. Cluster scoped resources ignore the namespace.
+	client func(clients *openshiftClients, namespace string) typedClient[T, L]
+}
+
+// listConfig :: returns the list config of the table
+func (r *resourceTable[T, L]) listConfig() *plugin.ListConfig {
+	keyColumns := getCommonOptionalKeyQuals(r.selectorQuals...)
+	if r.scope != namespacedScope {
+		keyColumns = getClusterScopedOptionalKeyQuals(r.selectorQuals...)
+	}
+
+	listConfig := &plugin.ListConfig{
+		Hydrate:     r.list,
+		KeyColumns:  keyColumns,
+		RetryConfig: r.retryConfig(),
+	}
+	if r.ignoreListErrors != nil {
+		listConfig.IgnoreConfig = &plugin.IgnoreConfig{
+			ShouldIgnoreErrorFunc: shouldIgnoreErrors(r.ignoreListErrors...),
+		}
+	}
+
+	return listConfig
+}
+
+// getConfig :: returns the get config of the table
+func (r *resourceTable[T, L]) getConfig() *plugin.GetConfig {
+	getConfig := &plugin.GetConfig{
+		KeyColumns:  getCommonGetKeyQuals("name", "namespace"),
+		Hydrate:     r.get,
+		RetryConfig: r.retryConfig(),
+	}
+	if r.scope != namespacedScope {
+		getConfig.KeyColumns = getCommonGetKeyQuals("name")
+	}
+	if r.ignoreGetErrors != nil {
+		getConfig.IgnoreConfig = &plugin.IgnoreConfig{
+			ShouldIgnoreErrorFunc: shouldIgnoreErrors(r.ignoreGetErrors...),
+		}
+	}
+
+	return getConfig
+}
+
+// retryConfig :: returns the retry config of the list and get calls of the table
+func (r *resourceTable[T, L]) retryConfig() *plugin.RetryConfig {
+	retryErrors := r.retryErrors
+	if retryErrors == nil {
+		retryErrors = defaultRetryErrors
+	}
+
+	return &plugin.RetryConfig{
+		ShouldRetryErrorFunc: shouldRetryError(retryErrors...),
+	}
+}
+
+// source :: returns the resource source backed by the typed client of the resource
+func (r *resourceTable[T, L]) source(clients *openshiftClients) resourceSource {
+	// the informer cache uses an empty object to know the type of the resource
+	var object T
+	object = reflect.New(reflect.TypeOf(object).Elem()).Interface().(T)
+
+	return resourceSource{
+		resource: r.resource,
+		object:   object,
+		list: func(ctx context.Context, namespace string, input v1.ListOptions) (runtime.Object, error) {
+			return r.client(clients, namespace).List(ctx, input)
+		},
+		watch: func(ctx context.Context, namespace string, input v1.ListOptions) (watch.Interface, error) {
+			return r.client(clients.watchClients(), namespace).Watch(ctx, input)
+		},
+		get: func(ctx context.Context, namespace string, name string) (runtime.Object, error) {
+			return r.client(clients, namespace).Get(ctx, name, v1.GetOptions{})
+		},
+	}
+}
+
+// LIST FUNCTION
+func (r *resourceTable[T, L]) list(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	clients, err := getClients(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error(r.name+".list", "connection_error", err)
+		return nil, err
+	}
+
+	input, err := getListOptions(d, r.selectorQuals...)
+	if err != nil {
+		plugin.Logger(ctx).Error(r.name+".list", "label_selector_error", err)
+		return nil, err
+	}
+
+	source := r.source(clients)
+	switch r.scope {
+	case namespacedScope:
+		err = listNamespacedResource(ctx, d, clients, input, source)
+	case projectScope:
+		err = listProjectResource(ctx, d, clients, input, source)
+	default:
+		err = listResource(ctx, d, clients, input, source)
+	}
+	if err != nil {
+		plugin.Logger(ctx).Error(r.name+".list", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// HYDRATE FUNCTIONS
+func (r *resourceTable[T, L]) get(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	name := d.EqualsQualString("name")
+	namespace := ""
+	if r.scope == namespacedScope {
+		namespace = d.EqualsQualString("namespace")
+	}
+
+	// Check if name or namespace is empty.
+	if name == "" || (r.scope == namespacedScope && namespace == "") {
+		return nil, nil
+	}
+
+	// Check if the namespace, or the project, is filtered out by the connection config.
+	if (r.scope == namespacedScope && !isNamespaceAllowed(d, namespace)) || (r.scope == projectScope && !isNamespaceAllowed(d, name)) {
+		return nil, nil
+	}
+
+	clients, err := getClients(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error(r.name+".get", "connection_error", err)
+		return nil, err
+	}
+
+	item, err := getResource(ctx, d, r.source(clients), namespace, name)
+	if err != nil {
+		plugin.Logger(ctx).Error(r.name+".get", "api_error", err)
+		return nil, err
+	}
+	object, err := meta.Accessor(item)
+	if err != nil {
+		plugin.Logger(ctx).Error(r.name+".get", "api_error", err)
+		return nil, err
+	}
+
+	// label selectors are not supported by get calls, so match the labels of the object
+	matches, err := matchesLabelSelectorQual(d, object.GetLabels())
+	if err != nil {
+		plugin.Logger(ctx).Error(r.name+".get", "label_selector_error", err)
+		return nil, err
+	}
+	if !matches {
+		return nil, nil
+	}
+
+	return item, nil
+}
+
+// getListOptions :: builds the list options of the query, pushing down the limit, label selector and field selector
+func getListOptions(d *plugin.QueryData, selectorQuals ...selectorQual) (v1.ListOptions, error) {
+	// Limiting the results
+	maxLimit := int64(1000)
+	if d.QueryContext.Limit != nil {
+		limit := *d.QueryContext.Limit
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	input := v1.ListOptions{
+		Limit: maxLimit,
+	}
+
+	labelSelector, err := getLabelSelectorQualValue(d, selectorQuals...)
+	if err != nil {
+		return input, err
+	}
+	input.LabelSelector = labelSelector.String()
+
+	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d, selectorQuals...)
+
+	if len(commonFieldSelectorValue) > 0 {
+		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
+	}
+
+	return input, nil
+}
+
+// watchFunc watches a resource in the namespace, or across all namespaces if empty.
+// Cluster scoped resources ignore the namespace.
+type watchFunc func(ctx context.Context, namespace string, input v1.ListOptions) (watch.Interface, error)
+
+// getFunc gets a single object of a resource by name. Cluster scoped resources ignore the namespace.
+type getFunc func(ctx context.Context, namespace string, name string) (runtime.Object, error)
+
+// resourceSource describes how to list, watch and get a resource,
+// so tables can be served either by the API server or by the informer cache.
+type resourceSource struct {
+	resource schema.GroupVersionResource
+	// object is an empty object of the resource type, e.g. &buildv1.Build{}
+	object runtime.Object
+	list   listPageFunc
+	watch  watchFunc
+	get    getFunc
+}
+
+// getResource :: gets the object from the informer cache if its informer is already running and synced,
+// otherwise from the API server
+func getResource(ctx context.Context, d *plugin.QueryData, source resourceSource, namespace string, name string) (runtime.Object, error) {
+	store, ok := getSyncedInformerStore(ctx, d, source)
+	if !ok {
+		return source.get(ctx, namespace, name)
+	}
+
+	key := name
+	if namespace != "" {
+		key = namespace + "/" + name
+	}
+	item, exists, err := store.GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(source.resource.GroupResource(), name)
+	}
+	return item.(runtime.Object), nil
+}
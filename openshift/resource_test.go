@@ -0,0 +1,399 @@
+package openshift
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	buildv1 "github.com/openshift/api/build/v1"
+	configv1 "github.com/openshift/api/config/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	oauthv1 "github.com/openshift/api/oauth/v1"
+	projectv1 "github.com/openshift/api/project/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	securityv1 "github.com/openshift/api/security/v1"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetListOptions(t *testing.T) {
+	limit := func(limit int64) *int64 { return &limit }
+
+	tests := []struct {
+		name              string
+		limit             *int64
+		quals             []*quals.Qual
+		selectorQuals     []selectorQual
+		wantLimit         int64
+		wantFieldSelector string
+		wantLabelSelector string
+		wantErr           bool
+	}{
+		{
+			name:      "defaults the page size",
+			wantLimit: 1000,
+		},
+		{
+			name:      "uses the query limit for small limits",
+			limit:     limit(10),
+			wantLimit: 10,
+		},
+		{
+			name:      "caps the page size for large limits",
+			limit:     limit(5000),
+			wantLimit: 1000,
+		},
+		{
+			name: "builds field selectors from name and namespace quals",
+			quals: []*quals.Qual{
+				newStringQual("name", quals.QualOperatorEqual, "b1"),
+				newStringQual("namespace", quals.QualOperatorNotEqual, "kube-system"),
+			},
+			wantLimit:         1000,
+			wantFieldSelector: "metadata.name=b1,metadata.namespace!=kube-system",
+		},
+		{
+			name: "builds field and label selectors from table selector quals",
+			quals: []*quals.Qual{
+				newStringQual("phase", quals.QualOperatorEqual, "Failed"),
+				newStringQual("build_config_name", quals.QualOperatorNotEqual, "web"),
+				newStringQual("label_selector", quals.QualOperatorEqual, "app=web"),
+			},
+			selectorQuals:     buildSelectorQuals,
+			wantLimit:         1000,
+			wantFieldSelector: "status=Failed",
+			wantLabelSelector: "app=web,openshift.io/build-config.name!=web",
+		},
+		{
+			name:          "ignores in lists",
+			quals:         []*quals.Qual{newStringListQual("name", "b1", "b2")},
+			selectorQuals: buildSelectorQuals,
+			wantLimit:     1000,
+		},
+		{
+			name: "combines label selectors",
+			quals: []*quals.Qual{
+				newStringQual("label_selector", quals.QualOperatorEqual, "app=web"),
+				newStringQual("label_selector", quals.QualOperatorEqual, "tier in (frontend)"),
+			},
+			wantLimit:         1000,
+			wantLabelSelector: "app=web,tier in (frontend)",
+		},
+		{
+			name:    "rejects label selector in lists",
+			quals:   []*quals.Qual{newStringListQual("label_selector", "app=web", "app=api")},
+			wantErr: true,
+		},
+		{
+			name:    "rejects invalid label selectors",
+			quals:   []*quals.Qual{newStringQual("label_selector", quals.QualOperatorEqual, "app in web")},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input, err := getListOptions(newTestQueryData(openshiftConfig{}, test.limit, test.quals...), test.selectorQuals...)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("getListOptions() returned %v, want error %v", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			if input.Limit != test.wantLimit {
+				t.Errorf("Limit = %d, want %d", input.Limit, test.wantLimit)
+			}
+			if input.FieldSelector != test.wantFieldSelector {
+				t.Errorf("FieldSelector = %q, want %q", input.FieldSelector, test.wantFieldSelector)
+			}
+			if input.LabelSelector != test.wantLabelSelector {
+				t.Errorf("LabelSelector = %q, want %q", input.LabelSelector, test.wantLabelSelector)
+			}
+		})
+	}
+}
+
+func TestResourceTableSources(t *testing.T) {
+	objectMeta := v1.ObjectMeta{Name: "object", Namespace: "ns"}
+	clusterObjectMeta := v1.ObjectMeta{Name: "object"}
+	clients, _ := newFakeClients(t,
+		&appsv1.DeploymentConfig{ObjectMeta: objectMeta},
+		&buildv1.Build{ObjectMeta: objectMeta},
+		&buildv1.BuildConfig{ObjectMeta: objectMeta},
+		&configv1.ClusterOperator{ObjectMeta: clusterObjectMeta},
+		&configv1.ClusterVersion{ObjectMeta: clusterObjectMeta},
+		&configv1.APIServer{ObjectMeta: clusterObjectMeta},
+		&configv1.Authentication{ObjectMeta: clusterObjectMeta},
+		&configv1.Console{ObjectMeta: clusterObjectMeta},
+		&configv1.DNS{ObjectMeta: clusterObjectMeta},
+		&configv1.FeatureGate{ObjectMeta: clusterObjectMeta},
+		&configv1.Image{ObjectMeta: clusterObjectMeta},
+		&configv1.Infrastructure{ObjectMeta: clusterObjectMeta},
+		&configv1.Ingress{ObjectMeta: clusterObjectMeta},
+		&configv1.Network{ObjectMeta: clusterObjectMeta},
+		&configv1.OAuth{ObjectMeta: clusterObjectMeta},
+		&configv1.Proxy{ObjectMeta: clusterObjectMeta},
+		&configv1.Scheduler{ObjectMeta: clusterObjectMeta},
+		&imagev1.ImageStream{ObjectMeta: objectMeta},
+		&routev1.Route{ObjectMeta: objectMeta},
+		&oauthv1.OAuthAccessToken{ObjectMeta: clusterObjectMeta},
+		&projectv1.Project{ObjectMeta: clusterObjectMeta},
+		&securityv1.SecurityContextConstraints{ObjectMeta: clusterObjectMeta},
+		&userv1.User{ObjectMeta: clusterObjectMeta},
+	)
+
+	tests := []struct {
+		name      string
+		source    resourceSource
+		namespace string
+	}{
+		{name: "openshift_build", source: buildResource.source(clients), namespace: "ns"},
+		{name: "openshift_build_config", source: buildConfigResource.source(clients), namespace: "ns"},
+		{name: "openshift_cluster_operator", source: clusterOperatorResource.source(clients)},
+		{name: "openshift_cluster_version", source: clusterVersionResource.source(clients)},
+		{name: "openshift_config_api_server", source: apiServerConfigResource.source(clients)},
+		{name: "openshift_config_authentication", source: authenticationConfigResource.source(clients)},
+		{name: "openshift_config_console", source: consoleConfigResource.source(clients)},
+		{name: "openshift_config_dns", source: dnsConfigResource.source(clients)},
+		{name: "openshift_config_feature_gate", source: featureGateConfigResource.source(clients)},
+		{name: "openshift_config_image", source: imageConfigResource.source(clients)},
+		{name: "openshift_config_infrastructure", source: infrastructureConfigResource.source(clients)},
+		{name: "openshift_config_ingress", source: ingressConfigResource.source(clients)},
+		{name: "openshift_config_network", source: networkConfigResource.source(clients)},
+		{name: "openshift_config_oauth", source: oauthConfigResource.source(clients)},
+		{name: "openshift_config_proxy", source: proxyConfigResource.source(clients)},
+		{name: "openshift_config_scheduler", source: schedulerConfigResource.source(clients)},
+		{name: "openshift_deployment_config", source: deploymentConfigResource.source(clients), namespace: "ns"},
+		{name: "openshift_image_stream", source: imageStreamResource.source(clients), namespace: "ns"},
+		{name: "openshift_route", source: routeResource.source(clients), namespace: "ns"},
+		{name: "openshift_oauth_access_token", source: oauthAccessTokenResource.source(clients)},
+		{name: "openshift_project", source: projectResource.source(clients)},
+		{name: "openshift_security_context_constraint", source: securityContextConstraintResource.source(clients)},
+		{name: "openshift_user", source: userResource.source(clients)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			streamer := newTestStreamer(0)
+			if _, err := listPages(newTestContext(), streamer.itemStreamer, "", v1.ListOptions{Limit: 1000}, test.source.list); err != nil {
+				t.Fatal(err)
+			}
+			if len(streamer.items) != 1 {
+				t.Fatalf("listed %d items, want 1", len(streamer.items))
+			}
+			if got, want := runtimeTypeName(streamer.items[0]), runtimeTypeName(test.source.object); got != want {
+				t.Errorf("listed a %s, want a %s", got, want)
+			}
+
+			object, err := test.source.get(newTestContext(), test.namespace, "object")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := runtimeTypeName(object), runtimeTypeName(test.source.object); got != want {
+				t.Errorf("got a %s, want a %s", got, want)
+			}
+		})
+	}
+}
+
+func TestResourceTableSourceWatchesWithWatchClients(t *testing.T) {
+	clients, fakes := newFakeClients(t)
+	watchClients, watchFakes := newFakeClients(t)
+	clients.watch = watchClients
+
+	watcher, err := buildResource.source(clients).watch(newTestContext(), "ns", v1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	watcher.Stop()
+
+	if actions := watchFakes.build.Actions(); len(actions) != 1 || actions[0].GetVerb() != "watch" {
+		t.Errorf("expected the watch clients to watch the builds, got %v", actions)
+	}
+	if actions := fakes.build.Actions(); len(actions) != 0 {
+		t.Errorf("expected the clients not to be used to watch, got %v", actions)
+	}
+}
+
+func runtimeTypeName(object interface{}) string {
+	return fmt.Sprintf("%T", object)
+}
+
+func TestResourceTableGet(t *testing.T) {
+	clients, fakes := newFakeClients(t,
+		&routev1.Route{ObjectMeta: v1.ObjectMeta{Name: "web", Namespace: "team-a", Labels: map[string]string{"app": "web"}}},
+		&routev1.Route{ObjectMeta: v1.ObjectMeta{Name: "web", Namespace: "openshift-console"}},
+	)
+	injectClients(t, clients)
+
+	tests := []struct {
+		name         string
+		config       openshiftConfig
+		quals        []*quals.Qual
+		wantName     string
+		wantNotFound bool
+		wantCalls    int
+	}{
+		{
+			name:      "gets the object",
+			quals:     []*quals.Qual{newStringQual("name", "=", "web"), newStringQual("namespace", "=", "team-a")},
+			wantName:  "web",
+			wantCalls: 1,
+		},
+		{
+			name:      "matches the label selector",
+			quals:     []*quals.Qual{newStringQual("name", "=", "web"), newStringQual("namespace", "=", "team-a"), newStringQual("label_selector", "=", "app=api")},
+			wantCalls: 1,
+		},
+		{
+			name:         "returns not found errors",
+			quals:        []*quals.Qual{newStringQual("name", "=", "missing"), newStringQual("namespace", "=", "team-a")},
+			wantNotFound: true,
+			wantCalls:    1,
+		},
+		{
+			name:   "skips filtered namespaces",
+			config: openshiftConfig{ExcludeNamespaces: []string{"openshift-*"}},
+			quals:  []*quals.Qual{newStringQual("name", "=", "web"), newStringQual("namespace", "=", "openshift-console")},
+		},
+		{
+			name:  "skips empty namespaces",
+			quals: []*quals.Qual{newStringQual("name", "=", "web")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakes.route.ClearActions()
+
+			item, err := routeResource.get(newTestContext(), newTestQueryData(test.config, nil, test.quals...), nil)
+			if test.wantNotFound != apierrors.IsNotFound(err) {
+				t.Fatalf("get returned %v, want not found %v", err, test.wantNotFound)
+			}
+			if err == nil && test.wantNotFound {
+				t.Fatal("get returned no error")
+			}
+
+			name := ""
+			if route, ok := item.(*routev1.Route); ok {
+				name = route.Name
+			} else if item != nil {
+				t.Fatalf("get returned a %T, want a *routev1.Route", item)
+			}
+			if name != test.wantName {
+				t.Errorf("get returned %q, want %q", name, test.wantName)
+			}
+			if calls := len(fakes.route.Actions()); calls != test.wantCalls {
+				t.Errorf("made %d API calls, want %d", calls, test.wantCalls)
+			}
+		})
+	}
+}
+
+func TestResourceTableErrorConfig(t *testing.T) {
+	forbidden := apierrors.NewForbidden(buildv1.Resource("builds"), "", errors.New("denied"))
+	tooManyRequests := apierrors.NewTooManyRequests("slow down", 0)
+	conflict := apierrors.NewConflict(buildv1.Resource("builds"), "b1", errors.New("conflict"))
+
+	overridden := &resourceTable[*buildv1.Build, *buildv1.BuildList]{
+		name:             "openshift_build",
+		resource:         buildResource.resource,
+		ignoreListErrors: []errorPredicate{apierrors.IsForbidden},
+		retryErrors:      []errorPredicate{apierrors.IsConflict},
+	}
+
+	tests := []struct {
+		name       string
+		listConfig *plugin.ListConfig
+		getConfig  *plugin.GetConfig
+		wantIgnore bool
+		retryErr   error
+		noRetryErr error
+	}{
+		{name: "defaults", listConfig: buildResource.listConfig(), getConfig: buildResource.getConfig(), retryErr: tooManyRequests, noRetryErr: conflict},
+		{name: "overridden", listConfig: overridden.listConfig(), getConfig: overridden.getConfig(), wantIgnore: true, retryErr: conflict, noRetryErr: tooManyRequests},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ignore := test.listConfig.IgnoreConfig != nil && test.listConfig.IgnoreConfig.ShouldIgnoreErrorFunc(newTestContext(), nil, nil, forbidden)
+			if ignore != test.wantIgnore {
+				t.Errorf("list ignores forbidden errors = %v, want %v", ignore, test.wantIgnore)
+			}
+
+			for _, retryConfig := range []*plugin.RetryConfig{test.listConfig.RetryConfig, test.getConfig.RetryConfig} {
+				if !retryConfig.ShouldRetryErrorFunc(newTestContext(), nil, nil, test.retryErr) {
+					t.Errorf("expected %v to be retried", test.retryErr)
+				}
+				if retryConfig.ShouldRetryErrorFunc(newTestContext(), nil, nil, test.noRetryErr) {
+					t.Errorf("expected %v not to be retried", test.noRetryErr)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceTableGetConfig(t *testing.T) {
+	forbidden := apierrors.NewForbidden(projectv1.Resource("projects"), "secret", errors.New("denied"))
+
+	tests := []struct {
+		name       string
+		getConfig  *plugin.GetConfig
+		wantIgnore bool
+		keyColumns []string
+	}{
+		{name: "namespaced", getConfig: buildResource.getConfig(), keyColumns: []string{"name", "namespace", "label_selector"}},
+		{name: "cluster scoped", getConfig: userResource.getConfig(), keyColumns: []string{"name", "label_selector"}},
+		{name: "ignores forbidden projects", getConfig: projectResource.getConfig(), wantIgnore: true, keyColumns: []string{"name", "label_selector"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keyColumns := []string{}
+			for _, keyColumn := range test.getConfig.KeyColumns {
+				keyColumns = append(keyColumns, keyColumn.Name)
+			}
+			if !slices.Equal(keyColumns, test.keyColumns) {
+				t.Errorf("key columns %v, want %v", keyColumns, test.keyColumns)
+			}
+
+			ignore := test.getConfig.IgnoreConfig != nil && test.getConfig.IgnoreConfig.ShouldIgnoreErrorFunc(newTestContext(), nil, nil, forbidden)
+			if ignore != test.wantIgnore {
+				t.Errorf("ignores forbidden errors = %v, want %v", ignore, test.wantIgnore)
+			}
+		})
+	}
+}
+
+func TestMatchesLabelSelectorQual(t *testing.T) {
+	objectLabels := map[string]string{"app": "web", "tier": "frontend"}
+
+	tests := []struct {
+		name    string
+		quals   []*quals.Qual
+		want    bool
+		wantErr bool
+	}{
+		{name: "no label selector", want: true},
+		{name: "matching", quals: []*quals.Qual{newStringQual("label_selector", quals.QualOperatorEqual, "app=web")}, want: true},
+		{name: "not matching", quals: []*quals.Qual{newStringQual("label_selector", quals.QualOperatorEqual, "app=api")}},
+		{name: "in list", quals: []*quals.Qual{newStringListQual("label_selector", "app=web", "app=api")}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := matchesLabelSelectorQual(newTestQueryData(openshiftConfig{}, nil, test.quals...), objectLabels)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("matchesLabelSelectorQual() returned %v, want error %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("matchesLabelSelectorQual() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -2,28 +2,38 @@ package openshift
 
 import (
 	"context"
-	"strings"
 
-	client_v1 "github.com/openshift/client-go/build/clientset/versioned/typed/build/v1"
+	buildv1 "github.com/openshift/api/build/v1"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// buildSelectorQuals are the columns the API server can filter builds by
+var buildSelectorQuals = []selectorQual{
+	{Column: "phase", Field: "status"},
+	{Column: "build_config_name", Label: "openshift.io/build-config.name"},
+}
+
+// buildResource is the resource served by the openshift_build table
+var buildResource = &resourceTable[*buildv1.Build, *buildv1.BuildList]{
+	name:          "openshift_build",
+	resource:      buildv1.GroupVersion.WithResource("builds"),
+	scope:         namespacedScope,
+	selectorQuals: buildSelectorQuals,
+	client: func(clients *openshiftClients, namespace string) typedClient[*buildv1.Build, *buildv1.BuildList] {
+		return clients.Build.Builds(namespace)
+	},
+}
+
 //// TABLE DEFINITION
 func tableOpenShiftBuild(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "openshift_build",
-		Description: "Retrieve information about OpenShift builds.",
-		List: &plugin.ListConfig{
-			Hydrate:    listBuilds,
-			KeyColumns: getCommonOptionalKeyQuals(),
-		},
-		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
-			Hydrate:    getBuild,
-		},
+		Name:              "openshift_build",
+		Description:       "Retrieve information about OpenShift builds.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              buildResource.listConfig(),
+		Get:               buildResource.getConfig(),
 		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "common_spec",
@@ -91,6 +101,12 @@ func tableOpenShiftBuild(ctx context.Context) *plugin.Table {
 				Type:        proto.ColumnType_JSON,
 				Transform:   transform.FromField("Status.Config"),
 			},
+			{
+				Name:        "build_config_name",
+				Description: "Name of the BuildConfig this Build is based on.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Config.Name"),
+			},
 			{
 				Name:        "output",
 				Description: "Output describes the Docker image the build has produced.",
@@ -126,89 +142,3 @@ func tableOpenShiftBuild(ctx context.Context) *plugin.Table {
 		}),
 	}
 }
-
-// LIST FUNCTION
-func listBuilds(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	config, err := getClient(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_build.listBuilds", "connection_error", err)
-		return nil, err
-	}
-	client, err := client_v1.NewForConfig(config)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_build.listBuilds", "NewForConfig_error", err)
-		return nil, err
-	}
-
-	// Limiting the results
-	maxLimit := int64(1000)
-	if d.QueryContext.Limit != nil {
-		limit := *d.QueryContext.Limit
-		if limit < maxLimit {
-			maxLimit = limit
-		}
-	}
-
-	input := v1.ListOptions{
-		Limit: maxLimit,
-	}
-
-	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
-
-	if len(commonFieldSelectorValue) > 0 {
-		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
-	}
-
-	for {
-		response, err := client.Builds("").List(ctx, input)
-		if err != nil {
-			plugin.Logger(ctx).Error("openshift_build.listBuilds", "api_error", err)
-			return nil, err
-		}
-		for _, build := range response.Items {
-			d.StreamListItem(ctx, build)
-
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
-			}
-		}
-		if response.Continue != "" {
-			input.Continue = response.Continue
-		} else {
-			break
-		}
-	}
-
-	return nil, nil
-}
-
-// HYDRATE FUNCTIONS
-func getBuild(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	name := d.EqualsQualString("name")
-	namespace := d.EqualsQualString("namespace")
-
-	// Check if name or namespace is empty.
-	if name == "" || namespace == "" {
-		return nil, nil
-	}
-
-	config, err := getClient(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_build.getBuild", "connection_error", err)
-		return nil, err
-	}
-	client, err := client_v1.NewForConfig(config)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_build.getBuild", "NewForConfig_error", err)
-		return nil, err
-	}
-
-	build, err := client.Builds(namespace).Get(ctx, name, v1.GetOptions{})
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_build.getBuild", "api_error", err)
-		return nil, err
-	}
-
-	return build, nil
-}
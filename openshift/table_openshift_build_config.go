@@ -2,28 +2,31 @@ package openshift
 
 import (
 	"context"
-	"strings"
 
-	client_v1 "github.com/openshift/client-go/build/clientset/versioned/typed/build/v1"
+	buildv1 "github.com/openshift/api/build/v1"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// buildConfigResource is the resource served by the openshift_build_config table
+var buildConfigResource = &resourceTable[*buildv1.BuildConfig, *buildv1.BuildConfigList]{
+	name:     "openshift_build_config",
+	resource: buildv1.GroupVersion.WithResource("buildconfigs"),
+	scope:    namespacedScope,
+	client: func(clients *openshiftClients, namespace string) typedClient[*buildv1.BuildConfig, *buildv1.BuildConfigList] {
+		return clients.Build.BuildConfigs(namespace)
+	},
+}
+
 //// TABLE DEFINITION
 func tableOpenShiftBuildConfig(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "openshift_build_config",
-		Description: "Retrieve information about OpenShift build configs.",
-		List: &plugin.ListConfig{
-			Hydrate:    listBuildConfigs,
-			KeyColumns: getCommonOptionalKeyQuals(),
-		},
-		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
-			Hydrate:    getBuildConfig,
-		},
+		Name:              "openshift_build_config",
+		Description:       "Retrieve information about OpenShift build configs.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              buildConfigResource.listConfig(),
+		Get:               buildConfigResource.getConfig(),
 		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "common_spec",
@@ -78,91 +81,3 @@ func tableOpenShiftBuildConfig(ctx context.Context) *plugin.Table {
 		}),
 	}
 }
-
-// LIST FUNCTION
-
-func listBuildConfigs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	config, err := getClient(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_build_config.listBuildConfigs", "connection_error", err)
-		return nil, err
-	}
-	client, err := client_v1.NewForConfig(config)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_build_config.listBuildConfigs", "NewForConfig_error", err)
-		return nil, err
-	}
-
-	// Limiting the results
-	maxLimit := int64(1000)
-	if d.QueryContext.Limit != nil {
-		limit := *d.QueryContext.Limit
-		if limit < maxLimit {
-			maxLimit = limit
-		}
-	}
-
-	input := v1.ListOptions{
-		Limit: maxLimit,
-	}
-
-	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
-
-	if len(commonFieldSelectorValue) > 0 {
-		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
-	}
-
-	for {
-		response, err := client.BuildConfigs("").List(ctx, input)
-		if err != nil {
-			plugin.Logger(ctx).Error("openshift_build_config.listBuildConfigs", "api_error", err)
-			return nil, err
-		}
-		for _, buildConfig := range response.Items {
-			d.StreamListItem(ctx, buildConfig)
-
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
-			}
-		}
-		if response.Continue != "" {
-			input.Continue = response.Continue
-		} else {
-			break
-		}
-	}
-
-	return nil, nil
-}
-
-// HYDRATE FUNCTIONS
-
-func getBuildConfig(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	name := d.EqualsQualString("name")
-	namespace := d.EqualsQualString("namespace")
-
-	// Check if name or namespace is empty.
-	if name == "" || namespace == "" {
-		return nil, nil
-	}
-
-	config, err := getClient(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_build_config.getBuildConfig", "connection_error", err)
-		return nil, err
-	}
-	client, err := client_v1.NewForConfig(config)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_build_config.getBuildConfig", "NewForConfig_error", err)
-		return nil, err
-	}
-
-	buildConfig, err := client.BuildConfigs(namespace).Get(ctx, name, v1.GetOptions{})
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_build_config.getBuildConfig", "api_error", err)
-		return nil, err
-	}
-
-	return buildConfig, nil
-}
@@ -0,0 +1,137 @@
+package openshift
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// clusterOperatorResource is the resource served by the openshift_cluster_operator table
+var clusterOperatorResource = &resourceTable[*configv1.ClusterOperator, *configv1.ClusterOperatorList]{
+	name:     "openshift_cluster_operator",
+	resource: configv1.GroupVersion.WithResource("clusteroperators"),
+	scope:    clusterScope,
+	client: func(clients *openshiftClients, _ string) typedClient[*configv1.ClusterOperator, *configv1.ClusterOperatorList] {
+		return clients.Config.ClusterOperators()
+	},
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftClusterOperator(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_cluster_operator",
+		Description:       "Retrieve information about OpenShift cluster operators.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              clusterOperatorResource.listConfig(),
+		Get:               clusterOperatorResource.getConfig(),
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "available",
+				Description: "Available indicates the operand is functional and available in the cluster. Null if the status of the condition is unknown.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionStatus, configv1.OperatorAvailable),
+			},
+			{
+				Name:        "available_reason",
+				Description: "A CamelCase reason for the last transition of the Available condition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionReason, configv1.OperatorAvailable),
+			},
+			{
+				Name:        "available_message",
+				Description: "A human-readable message describing the last transition of the Available condition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionMessage, configv1.OperatorAvailable),
+			},
+			{
+				Name:        "progressing",
+				Description: "Progressing indicates the operator is actively rolling out new code, propagating config changes, or moving from one steady state to another. Null if the status of the condition is unknown.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionStatus, configv1.OperatorProgressing),
+			},
+			{
+				Name:        "progressing_reason",
+				Description: "A CamelCase reason for the last transition of the Progressing condition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionReason, configv1.OperatorProgressing),
+			},
+			{
+				Name:        "progressing_message",
+				Description: "A human-readable message describing the last transition of the Progressing condition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionMessage, configv1.OperatorProgressing),
+			},
+			{
+				Name:        "degraded",
+				Description: "Degraded indicates the component is not matching its desired state over a period of time, resulting in a lower quality of service. Null if the status of the condition is unknown.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionStatus, configv1.OperatorDegraded),
+			},
+			{
+				Name:        "degraded_reason",
+				Description: "A CamelCase reason for the last transition of the Degraded condition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionReason, configv1.OperatorDegraded),
+			},
+			{
+				Name:        "degraded_message",
+				Description: "A human-readable message describing the last transition of the Degraded condition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionMessage, configv1.OperatorDegraded),
+			},
+			{
+				Name:        "upgradeable",
+				Description: "Upgradeable indicates whether the operator is safe to upgrade based on the current cluster state. Null if the operator does not report the condition or its status is unknown.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionStatus, configv1.OperatorUpgradeable),
+			},
+			{
+				Name:        "upgradeable_reason",
+				Description: "A CamelCase reason for the last transition of the Upgradeable condition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionReason, configv1.OperatorUpgradeable),
+			},
+			{
+				Name:        "upgradeable_message",
+				Description: "A human-readable message describing the last transition of the Upgradeable condition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionMessage, configv1.OperatorUpgradeable),
+			},
+			{
+				Name:        "conditions",
+				Description: "Conditions describe the state of the operator's managed and monitored components.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.Conditions"),
+			},
+			{
+				Name:        "versions",
+				Description: "Versions is an array of the versions of the operator and its operands, e.g. the operator version and the version of each component it manages.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.Versions"),
+			},
+			{
+				Name:        "related_objects",
+				Description: "RelatedObjects is a list of objects that are interesting or related to the operator, e.g. the namespaces, deployments and custom resources it manages.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.RelatedObjects"),
+			},
+			{
+				Name:        "extension",
+				Description: "Extension contains any additional status information specific to the operator which owns this status object.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.Extension"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
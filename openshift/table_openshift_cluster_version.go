@@ -0,0 +1,231 @@
+package openshift
+
+import (
+	"context"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// clusterVersionFailing is the condition the cluster version operator reports when it cannot reach the desired version
+const clusterVersionFailing configv1.ClusterStatusConditionType = "Failing"
+
+// clusterVersionResource is the resource served by the openshift_cluster_version table
+var clusterVersionResource = &resourceTable[*configv1.ClusterVersion, *configv1.ClusterVersionList]{
+	name:     "openshift_cluster_version",
+	resource: configv1.GroupVersion.WithResource("clusterversions"),
+	scope:    clusterScope,
+	client: func(clients *openshiftClients, _ string) typedClient[*configv1.ClusterVersion, *configv1.ClusterVersionList] {
+		return clients.Config.ClusterVersions()
+	},
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftClusterVersion(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_cluster_version",
+		Description:       "Retrieve information about the OpenShift cluster version, its update history and available updates.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              clusterVersionResource.listConfig(),
+		Get:               clusterVersionResource.getConfig(),
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "cluster_id",
+				Description: "ClusterID uniquely identifies this cluster.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.ClusterID"),
+			},
+			{
+				Name:        "version",
+				Description: "The version the cluster is running, i.e. the version of the most recent completed update.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.History").Transform(clusterVersionCurrentVersion),
+			},
+			{
+				Name:        "desired_version",
+				Description: "The version of the release the cluster is reconciling towards.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Desired.Version"),
+			},
+			{
+				Name:        "desired_image",
+				Description: "The container image of the release the cluster is reconciling towards.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Desired.Image"),
+			},
+			{
+				Name:        "desired",
+				Description: "Desired is the version that the cluster is reconciling towards, including its image, URL and channels.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.Desired"),
+			},
+			{
+				Name:        "desired_update",
+				Description: "DesiredUpdate is an optional field that indicates the desired value of the cluster version, as requested by an administrator.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.DesiredUpdate"),
+			},
+			{
+				Name:        "channel",
+				Description: "Channel is an identifier for explicitly requesting that a non-default set of updates be applied to this cluster, e.g. stable-4.14.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.Channel"),
+			},
+			{
+				Name:        "upstream",
+				Description: "Upstream may be used to specify the preferred update server. By default the Red Hat update service is used.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.Upstream"),
+			},
+			{
+				Name:        "available",
+				Description: "Available indicates the cluster version operator is functional and the cluster is available. Null if the status of the condition is unknown.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionStatus, configv1.OperatorAvailable),
+			},
+			{
+				Name:        "available_message",
+				Description: "A human-readable message describing the last transition of the Available condition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionMessage, configv1.OperatorAvailable),
+			},
+			{
+				Name:        "progressing",
+				Description: "Progressing indicates the cluster is being updated to the desired version. Null if the status of the condition is unknown.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionStatus, configv1.OperatorProgressing),
+			},
+			{
+				Name:        "progressing_message",
+				Description: "A human-readable message describing the last transition of the Progressing condition, e.g. the progress of an update.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionMessage, configv1.OperatorProgressing),
+			},
+			{
+				Name:        "failing",
+				Description: "Failing indicates the cluster version operator cannot reach the desired version. Null if the status of the condition is unknown.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionStatus, clusterVersionFailing),
+			},
+			{
+				Name:        "failing_reason",
+				Description: "A CamelCase reason for the last transition of the Failing condition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionReason, clusterVersionFailing),
+			},
+			{
+				Name:        "failing_message",
+				Description: "A human-readable message describing the last transition of the Failing condition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionMessage, clusterVersionFailing),
+			},
+			{
+				Name:        "upgradeable",
+				Description: "Upgradeable indicates whether the cluster can be updated to the next minor version. Null if the condition is not reported or its status is unknown.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionStatus, configv1.OperatorUpgradeable),
+			},
+			{
+				Name:        "upgradeable_reason",
+				Description: "A CamelCase reason for the last transition of the Upgradeable condition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionReason, configv1.OperatorUpgradeable),
+			},
+			{
+				Name:        "upgradeable_message",
+				Description: "A human-readable message describing the last transition of the Upgradeable condition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionMessage, configv1.OperatorUpgradeable),
+			},
+			{
+				Name:        "retrieved_updates",
+				Description: "RetrievedUpdates indicates whether the available updates were retrieved from the upstream update server. Null if the status of the condition is unknown.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Status.Conditions").TransformP(clusterConditionStatus, configv1.RetrievedUpdates),
+			},
+			{
+				Name:        "conditions",
+				Description: "Conditions provides information about the cluster version, e.g. whether it is available, progressing, failing or upgradeable.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.Conditions"),
+			},
+			{
+				Name:        "available_updates",
+				Description: "AvailableUpdates contains the updates recommended for this cluster, with their version, image and channels.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.AvailableUpdates"),
+			},
+			{
+				Name:        "conditional_updates",
+				Description: "ConditionalUpdates contains the updates which may be recommended for this cluster if it meets the conditions of their known risks.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.ConditionalUpdates"),
+			},
+			{
+				Name:        "history",
+				Description: "History contains the completed and partial updates applied to the cluster, most recent first. See the openshift_cluster_version_history table for one row per update.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.History"),
+			},
+			{
+				Name:        "observed_generation",
+				Description: "ObservedGeneration reports which version of the spec is being synced.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Status.ObservedGeneration"),
+			},
+			{
+				Name:        "version_hash",
+				Description: "VersionHash is a fingerprint of the content that the cluster will be updated with.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.VersionHash"),
+			},
+			{
+				Name:        "capabilities",
+				Description: "Capabilities describes the state of the optional, core cluster components, which are enabled and which are known.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.Capabilities"),
+			},
+			{
+				Name:        "spec_capabilities",
+				Description: "Capabilities configures the installation of optional, core cluster components.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.Capabilities"),
+			},
+			{
+				Name:        "overrides",
+				Description: "Overrides is a list of components that are marked as unmanaged by the cluster version operator.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.Overrides"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+// TRANSFORM FUNCTIONS
+
+// clusterVersionCurrentVersion :: returns the version of the most recent completed update, nil if none completed
+func clusterVersionCurrentVersion(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	history, ok := d.Value.([]configv1.UpdateHistory)
+	if !ok {
+		return nil, fmt.Errorf("invalid update history %T", d.Value)
+	}
+
+	// the history is ordered by the most recent update first
+	for _, update := range history {
+		if update.State == configv1.CompletedUpdate {
+			return update.Version, nil
+		}
+	}
+	return nil, nil
+}
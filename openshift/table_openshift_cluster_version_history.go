@@ -0,0 +1,153 @@
+package openshift
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// clusterVersionHistory is an update in the history of a cluster version, a row of the openshift_cluster_version_history table
+type clusterVersionHistory struct {
+	ClusterVersionName string
+	ClusterID          configv1.ClusterID
+	// Index is the position of the update in the history, 0 for the most recent update
+	Index   int
+	History configv1.UpdateHistory
+	// DurationSeconds is the time the update took to complete, nil for partial updates
+	DurationSeconds *int64
+}
+
+func newClusterVersionHistory(clusterVersion *configv1.ClusterVersion, index int) clusterVersionHistory {
+	row := clusterVersionHistory{
+		ClusterVersionName: clusterVersion.Name,
+		ClusterID:          clusterVersion.Spec.ClusterID,
+		Index:              index,
+		History:            clusterVersion.Status.History[index],
+	}
+	if completion := row.History.CompletionTime; completion != nil && row.History.State == configv1.CompletedUpdate {
+		duration := int64(completion.Sub(row.History.StartedTime.Time).Seconds())
+		row.DurationSeconds = &duration
+	}
+	return row
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftClusterVersionHistory(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_cluster_version_history",
+		Description:       "Retrieve the completed and partial updates applied to OpenShift clusters.",
+		GetMatrixItemFunc: BuildContextList,
+		List: &plugin.ListConfig{
+			Hydrate: listClusterVersionHistory,
+		},
+		Columns: append([]*plugin.Column{
+			{
+				Name:        "cluster_version_name",
+				Description: "Name of the cluster version the update belongs to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "cluster_id",
+				Description: "ClusterID uniquely identifies the cluster.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ClusterID"),
+			},
+			{
+				Name:        "history_index",
+				Description: "The position of the update in the history of the cluster version, 0 for the most recent update.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Index"),
+			},
+			{
+				Name:        "state",
+				Description: "State reflects whether the update was fully applied (Completed) or is in progress or was aborted (Partial).",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("History.State"),
+			},
+			{
+				Name:        "version",
+				Description: "Version is a semantic version identifying the update version.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("History.Version"),
+			},
+			{
+				Name:        "image",
+				Description: "Image is a container image location that contains the update.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("History.Image"),
+			},
+			{
+				Name:        "started_time",
+				Description: "StartedTime is the time at which the update was started.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("History.StartedTime").Transform(v1TimeToRFC3339),
+			},
+			{
+				Name:        "completion_time",
+				Description: "CompletionTime, if set, is when the update was fully applied. Null for partial updates.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("History.CompletionTime").Transform(v1TimeToRFC3339),
+			},
+			{
+				Name:        "duration_seconds",
+				Description: "The time the update took to complete, in seconds. Null for partial updates.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "verified",
+				Description: "Verified indicates whether the provided update was properly verified before it was installed.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("History.Verified"),
+			},
+			{
+				Name:        "accepted_risks",
+				Description: "AcceptedRisks records risks which were accepted to initiate the update, e.g. a failed verification or an unrecommended target. Null if no risks were accepted.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("History.AcceptedRisks").Transform(transform.NullIfZeroValue),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("History.Version"),
+			},
+		}, clusterColumns()...),
+	}
+}
+
+// LIST FUNCTION
+func listClusterVersionHistory(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	clients, err := getClients(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("openshift_cluster_version_history.list", "connection_error", err)
+		return nil, err
+	}
+
+	// stream a row for each update in the history of each cluster version
+	streamer := newItemStreamer(ctx, d)
+	streamer.streamItem = func(item interface{}) {
+		clusterVersion := item.(*configv1.ClusterVersion)
+		for index := range clusterVersion.Status.History {
+			d.StreamListItem(ctx, newClusterVersionHistory(clusterVersion, index))
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return
+			}
+		}
+	}
+
+	// the query limit applies to the history rows rather than the cluster versions, so it is not pushed down
+	input := v1.ListOptions{Limit: 1000}
+	if _, err := listPages(ctx, streamer, "", input, clusterVersionResource.source(clients).list); err != nil {
+		plugin.Logger(ctx).Error("openshift_cluster_version_history.list", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,89 @@
+package openshift
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// apiServerConfigResource is the resource served by the openshift_config_api_server table
+var apiServerConfigResource = &resourceTable[*configv1.APIServer, *configv1.APIServerList]{
+	name:     "openshift_config_api_server",
+	resource: configv1.GroupVersion.WithResource("apiservers"),
+	scope:    clusterScope,
+	client: func(clients *openshiftClients, _ string) typedClient[*configv1.APIServer, *configv1.APIServerList] {
+		return clients.Config.APIServers()
+	},
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftConfigAPIServer(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_config_api_server",
+		Description:       "Retrieve the cluster-wide configuration of the OpenShift API servers.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              apiServerConfigResource.listConfig(),
+		Get:               apiServerConfigResource.getConfig(),
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "tls_security_profile_type",
+				Description: "The type of the TLS security profile of the API servers, one of Old, Intermediate, Modern or Custom. Null if unset, in which case the default Intermediate profile is used.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.TLSSecurityProfile.Type"),
+			},
+			{
+				Name:        "tls_security_profile",
+				Description: "TLSSecurityProfile specifies settings for TLS connections for externally exposed servers, including the ciphers and minimum TLS version of Custom profiles.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.TLSSecurityProfile"),
+			},
+			{
+				Name:        "audit_profile",
+				Description: "The top-level audit profile applied to all requests sent to the OpenShift-provided API servers, e.g. Default, WriteRequestBodies, AllRequestBodies or None.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.Audit.Profile"),
+			},
+			{
+				Name:        "audit_custom_rules",
+				Description: "CustomRules specify profiles per group. These profiles take precedence over the top-level audit profile.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.Audit.CustomRules"),
+			},
+			{
+				Name:        "encryption_type",
+				Description: "The encryption type used to encrypt resources at the datastore layer, e.g. identity, aescbc or aesgcm. Null if unset, in which case identity, i.e. no encryption, is implied.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.Encryption.Type").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "client_ca",
+				Description: "Name of the ConfigMap in the openshift-config namespace containing a certificate bundle for the signers that will be recognized for incoming client certificates.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.ClientCA.Name").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "serving_certs",
+				Description: "ServingCerts is the TLS cert info for serving secure traffic to specific hostnames.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.ServingCerts"),
+			},
+			{
+				Name:        "additional_cors_allowed_origins",
+				Description: "AdditionalCORSAllowedOrigins lists additional, user-defined regular expressions describing hosts for which the API server allows access using the CORS headers.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.AdditionalCORSAllowedOrigins"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
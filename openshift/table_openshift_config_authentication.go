@@ -0,0 +1,71 @@
+package openshift
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// authenticationConfigResource is the resource served by the openshift_config_authentication table
+var authenticationConfigResource = &resourceTable[*configv1.Authentication, *configv1.AuthenticationList]{
+	name:     "openshift_config_authentication",
+	resource: configv1.GroupVersion.WithResource("authentications"),
+	scope:    clusterScope,
+	client: func(clients *openshiftClients, _ string) typedClient[*configv1.Authentication, *configv1.AuthenticationList] {
+		return clients.Config.Authentications()
+	},
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftConfigAuthentication(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_config_authentication",
+		Description:       "Retrieve the cluster-wide authentication configuration of OpenShift clusters.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              authenticationConfigResource.listConfig(),
+		Get:               authenticationConfigResource.getConfig(),
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "type",
+				Description: "Type identifies the cluster managed, user facing authentication mode in use, e.g. IntegratedOAuth or None. The default is IntegratedOAuth.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.Type"),
+			},
+			{
+				Name:        "service_account_issuer",
+				Description: "ServiceAccountIssuer is the identifier of the bound service account token issuer. The default is https://kubernetes.default.svc.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.ServiceAccountIssuer"),
+			},
+			{
+				Name:        "oauth_metadata",
+				Description: "Name of the ConfigMap in the openshift-config namespace containing the discovery endpoint data for OAuth 2.0 Authorization Server Metadata for an external OAuth server.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.OAuthMetadata.Name").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "webhook_token_authenticator",
+				Description: "WebhookTokenAuthenticator configures a remote token reviewer, to verify bearer tokens provisioned by an external authentication service.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.WebhookTokenAuthenticator"),
+			},
+			{
+				Name:        "integrated_oauth_metadata",
+				Description: "Name of the ConfigMap in the openshift-config-managed namespace containing the discovery endpoint data for the in-cluster integrated OAuth server.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.IntegratedOAuthMetadata.Name").Transform(transform.NullIfZeroValue),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
@@ -0,0 +1,53 @@
+package openshift
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// consoleConfigResource is the resource served by the openshift_config_console table
+var consoleConfigResource = &resourceTable[*configv1.Console, *configv1.ConsoleList]{
+	name:     "openshift_config_console",
+	resource: configv1.GroupVersion.WithResource("consoles"),
+	scope:    clusterScope,
+	client: func(clients *openshiftClients, _ string) typedClient[*configv1.Console, *configv1.ConsoleList] {
+		return clients.Config.Consoles()
+	},
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftConfigConsole(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_config_console",
+		Description:       "Retrieve the cluster-wide configuration of the OpenShift web console.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              consoleConfigResource.listConfig(),
+		Get:               consoleConfigResource.getConfig(),
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "console_url",
+				Description: "The URL for the console, derived from the host of the route created for the console.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.ConsoleURL"),
+			},
+			{
+				Name:        "logout_redirect",
+				Description: "An optional, absolute URL to redirect web browsers to after logging out of the console, e.g. to log out of the single sign-on session of the identity provider.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.Authentication.LogoutRedirect").Transform(transform.NullIfZeroValue),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
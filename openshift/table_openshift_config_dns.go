@@ -0,0 +1,65 @@
+package openshift
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// dnsConfigResource is the resource served by the openshift_config_dns table
+var dnsConfigResource = &resourceTable[*configv1.DNS, *configv1.DNSList]{
+	name:     "openshift_config_dns",
+	resource: configv1.GroupVersion.WithResource("dnses"),
+	scope:    clusterScope,
+	client: func(clients *openshiftClients, _ string) typedClient[*configv1.DNS, *configv1.DNSList] {
+		return clients.Config.DNSes()
+	},
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftConfigDNS(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_config_dns",
+		Description:       "Retrieve the cluster-wide DNS configuration of OpenShift clusters.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              dnsConfigResource.listConfig(),
+		Get:               dnsConfigResource.getConfig(),
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "base_domain",
+				Description: "BaseDomain is the base domain of the cluster. All managed DNS records will be sub-domains of this base.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.BaseDomain"),
+			},
+			{
+				Name:        "public_zone",
+				Description: "PublicZone is the location where all the DNS records that are publicly accessible to the internet exist. Null if no public records are created.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.PublicZone"),
+			},
+			{
+				Name:        "private_zone",
+				Description: "PrivateZone is the location where all the DNS records that are only available internally to the cluster exist. Null if no private records are created.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.PrivateZone"),
+			},
+			{
+				Name:        "platform",
+				Description: "Platform holds configuration specific to the underlying infrastructure provider for DNS.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.Platform"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
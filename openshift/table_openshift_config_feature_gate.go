@@ -0,0 +1,65 @@
+package openshift
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// featureGateConfigResource is the resource served by the openshift_config_feature_gate table
+var featureGateConfigResource = &resourceTable[*configv1.FeatureGate, *configv1.FeatureGateList]{
+	name:     "openshift_config_feature_gate",
+	resource: configv1.GroupVersion.WithResource("featuregates"),
+	scope:    clusterScope,
+	client: func(clients *openshiftClients, _ string) typedClient[*configv1.FeatureGate, *configv1.FeatureGateList] {
+		return clients.Config.FeatureGates()
+	},
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftConfigFeatureGate(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_config_feature_gate",
+		Description:       "Retrieve the feature gates enabled in OpenShift clusters.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              featureGateConfigResource.listConfig(),
+		Get:               featureGateConfigResource.getConfig(),
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "feature_set",
+				Description: "FeatureSet changes the list of features in the cluster, e.g. TechPreviewNoUpgrade or CustomNoUpgrade. Null for the default feature set.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.FeatureSet").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "custom_no_upgrade",
+				Description: "CustomNoUpgrade lists the feature gates enabled or disabled by the CustomNoUpgrade feature set. Turning this feature set on is not supported, cannot be undone, and prevents upgrades.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.CustomNoUpgrade"),
+			},
+			{
+				Name:        "feature_gates",
+				Description: "FeatureGates contains a list of enabled and disabled feature gates, keyed by the version of the cluster.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.FeatureGates"),
+			},
+			{
+				Name:        "conditions",
+				Description: "Conditions represent the observations of the current state of the feature gates.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.Conditions"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
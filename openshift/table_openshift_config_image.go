@@ -0,0 +1,89 @@
+package openshift
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// imageConfigResource is the resource served by the openshift_config_image table
+var imageConfigResource = &resourceTable[*configv1.Image, *configv1.ImageList]{
+	name:     "openshift_config_image",
+	resource: configv1.GroupVersion.WithResource("images"),
+	scope:    clusterScope,
+	client: func(clients *openshiftClients, _ string) typedClient[*configv1.Image, *configv1.ImageList] {
+		return clients.Config.Images()
+	},
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftConfigImage(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_config_image",
+		Description:       "Retrieve the cluster-wide image registry configuration of OpenShift clusters.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              imageConfigResource.listConfig(),
+		Get:               imageConfigResource.getConfig(),
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "allowed_registries",
+				Description: "AllowedRegistries are the only registries permitted for image pull and push actions. All other registries are denied.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.RegistrySources.AllowedRegistries"),
+			},
+			{
+				Name:        "blocked_registries",
+				Description: "BlockedRegistries cannot be used for image pull and push actions. All other registries are permitted.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.RegistrySources.BlockedRegistries"),
+			},
+			{
+				Name:        "insecure_registries",
+				Description: "InsecureRegistries are registries which do not have a valid TLS certificate or only support HTTP connections.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.RegistrySources.InsecureRegistries"),
+			},
+			{
+				Name:        "container_runtime_search_registries",
+				Description: "ContainerRuntimeSearchRegistries are registries that will be searched when pulling images that do not have fully qualified domains in their pull specs.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.RegistrySources.ContainerRuntimeSearchRegistries"),
+			},
+			{
+				Name:        "allowed_registries_for_import",
+				Description: "AllowedRegistriesForImport limits the container image registries that normal users may import images from.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.AllowedRegistriesForImport"),
+			},
+			{
+				Name:        "additional_trusted_ca",
+				Description: "Name of the ConfigMap in the openshift-config namespace containing additional CAs that are trusted during image stream import, pod image pull, build image pull and image registry pullthrough.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.AdditionalTrustedCA.Name").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "external_registry_hostnames",
+				Description: "ExternalRegistryHostnames provides the hostnames for the default external image registry.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.ExternalRegistryHostnames"),
+			},
+			{
+				Name:        "internal_registry_hostname",
+				Description: "InternalRegistryHostname is the hostname of the default internal image registry, set by the image registry operator.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.InternalRegistryHostname"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
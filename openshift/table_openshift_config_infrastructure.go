@@ -0,0 +1,101 @@
+package openshift
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// infrastructureConfigResource is the resource served by the openshift_config_infrastructure table
+var infrastructureConfigResource = &resourceTable[*configv1.Infrastructure, *configv1.InfrastructureList]{
+	name:     "openshift_config_infrastructure",
+	resource: configv1.GroupVersion.WithResource("infrastructures"),
+	scope:    clusterScope,
+	client: func(clients *openshiftClients, _ string) typedClient[*configv1.Infrastructure, *configv1.InfrastructureList] {
+		return clients.Config.Infrastructures()
+	},
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftConfigInfrastructure(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_config_infrastructure",
+		Description:       "Retrieve the cluster-wide infrastructure configuration of OpenShift clusters.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              infrastructureConfigResource.listConfig(),
+		Get:               infrastructureConfigResource.getConfig(),
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "infrastructure_name",
+				Description: "InfrastructureName uniquely identifies a cluster with a human friendly name.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.InfrastructureName"),
+			},
+			{
+				Name:        "platform_type",
+				Description: "The underlying infrastructure provider for the cluster, e.g. AWS, Azure, BareMetal, GCP, OpenStack, VSphere or None.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.PlatformStatus.Type"),
+			},
+			{
+				Name:        "platform_status",
+				Description: "PlatformStatus holds status information specific to the underlying infrastructure provider, e.g. the region of the cluster.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.PlatformStatus"),
+			},
+			{
+				Name:        "platform_spec",
+				Description: "PlatformSpec holds desired information specific to the underlying infrastructure provider.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.PlatformSpec"),
+			},
+			{
+				Name:        "api_server_url",
+				Description: "APIServerURL is the URL of the Kubernetes API server, used by components like the web console to tell users where to find the Kubernetes API.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.APIServerURL"),
+			},
+			{
+				Name:        "api_server_internal_url",
+				Description: "APIServerInternalURL is the URL components like kubelets use to contact the Kubernetes API server using the infrastructure provider rather than Kubernetes networking.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.APIServerInternalURL"),
+			},
+			{
+				Name:        "control_plane_topology",
+				Description: "ControlPlaneTopology expresses the expectations for operands that normally run on control nodes, one of HighlyAvailable, SingleReplica or External.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.ControlPlaneTopology"),
+			},
+			{
+				Name:        "infrastructure_topology",
+				Description: "InfrastructureTopology expresses the expectations for infrastructure services that do not run on control plane nodes, one of HighlyAvailable or SingleReplica.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.InfrastructureTopology"),
+			},
+			{
+				Name:        "cpu_partitioning",
+				Description: "CPUPartitioning expresses if CPU partitioning is enabled in the cluster, one of None or AllNodes.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.CPUPartitioning").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "cloud_config",
+				Description: "CloudConfig is a reference to a ConfigMap containing the cloud provider configuration file.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.CloudConfig"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
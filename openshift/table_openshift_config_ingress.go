@@ -0,0 +1,83 @@
+package openshift
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// ingressConfigResource is the resource served by the openshift_config_ingress table
+var ingressConfigResource = &resourceTable[*configv1.Ingress, *configv1.IngressList]{
+	name:     "openshift_config_ingress",
+	resource: configv1.GroupVersion.WithResource("ingresses"),
+	scope:    clusterScope,
+	client: func(clients *openshiftClients, _ string) typedClient[*configv1.Ingress, *configv1.IngressList] {
+		return clients.Config.Ingresses()
+	},
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftConfigIngress(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_config_ingress",
+		Description:       "Retrieve the cluster-wide ingress configuration of OpenShift clusters.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              ingressConfigResource.listConfig(),
+		Get:               ingressConfigResource.getConfig(),
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "domain",
+				Description: "Domain is used to generate a default host name for a route when the host name of the route is empty, following the pattern <route-name>.<route-namespace>.<domain>.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.Domain"),
+			},
+			{
+				Name:        "apps_domain",
+				Description: "AppsDomain is an optional domain to use instead of the one specified in the domain field when a route is created without specifying an explicit host.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.AppsDomain").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "required_hsts_policies",
+				Description: "RequiredHSTSPolicies specifies HSTS policies that are required to be set on newly created or updated routes matching their domain patterns and namespace selectors.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.RequiredHSTSPolicies"),
+			},
+			{
+				Name:        "component_routes",
+				Description: "ComponentRoutes is an optional list of routes managed by OpenShift components whose hostname and serving certificate are configured by a cluster administrator.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.ComponentRoutes"),
+			},
+			{
+				Name:        "load_balancer_platform_type",
+				Description: "The underlying infrastructure provider of the load balancer of the ingress controllers, e.g. AWS.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.LoadBalancer.Platform.Type").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "default_placement",
+				Description: "DefaultPlacement controls which nodes host the ingress router pods by default, one of ControlPlane or Workers.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.DefaultPlacement").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "status_component_routes",
+				Description: "The current status of the routes whose hostnames and serving certificates can be customized by a cluster administrator.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.ComponentRoutes"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
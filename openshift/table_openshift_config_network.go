@@ -0,0 +1,101 @@
+package openshift
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// networkConfigResource is the resource served by the openshift_config_network table
+var networkConfigResource = &resourceTable[*configv1.Network, *configv1.NetworkList]{
+	name:     "openshift_config_network",
+	resource: configv1.GroupVersion.WithResource("networks"),
+	scope:    clusterScope,
+	client: func(clients *openshiftClients, _ string) typedClient[*configv1.Network, *configv1.NetworkList] {
+		return clients.Config.Networks()
+	},
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftConfigNetwork(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_config_network",
+		Description:       "Retrieve the cluster-wide network configuration of OpenShift clusters.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              networkConfigResource.listConfig(),
+		Get:               networkConfigResource.getConfig(),
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "network_type",
+				Description: "NetworkType is the network plugin that is deployed, e.g. OpenShiftSDN or OVNKubernetes.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.NetworkType"),
+			},
+			{
+				Name:        "cluster_network",
+				Description: "The IP address pools used for pod IPs.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.ClusterNetwork"),
+			},
+			{
+				Name:        "service_network",
+				Description: "The IP address pools used for services.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.ServiceNetwork"),
+			},
+			{
+				Name:        "cluster_network_mtu",
+				Description: "ClusterNetworkMTU is the MTU for inter-pod networking.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Status.ClusterNetworkMTU"),
+			},
+			{
+				Name:        "service_node_port_range",
+				Description: "The port range allowed for services of type NodePort. Null if unset, in which case the default of 30000-32767 is used.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.ServiceNodePortRange").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "external_ip",
+				Description: "ExternalIP defines the configuration of the controllers that affect Service.ExternalIP. Null if external IPs are not allowed to be set.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.ExternalIP"),
+			},
+			{
+				Name:        "migration",
+				Description: "Migration contains the cluster network migration configuration, e.g. when migrating to another network plugin.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Status.Migration"),
+			},
+			{
+				Name:        "spec_network_type",
+				Description: "NetworkType is the network plugin that is to be deployed, as requested in the spec.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.NetworkType"),
+			},
+			{
+				Name:        "spec_cluster_network",
+				Description: "The IP address pools to use for pod IPs, as requested in the spec.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.ClusterNetwork"),
+			},
+			{
+				Name:        "spec_service_network",
+				Description: "The IP address pools to use for services, as requested in the spec.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.ServiceNetwork"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
@@ -0,0 +1,101 @@
+package openshift
+
+import (
+	"context"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// oauthConfigResource is the resource served by the openshift_config_oauth table
+var oauthConfigResource = &resourceTable[*configv1.OAuth, *configv1.OAuthList]{
+	name:     "openshift_config_oauth",
+	resource: configv1.GroupVersion.WithResource("oauths"),
+	scope:    clusterScope,
+	client: func(clients *openshiftClients, _ string) typedClient[*configv1.OAuth, *configv1.OAuthList] {
+		return clients.Config.OAuths()
+	},
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftConfigOAuth(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_config_oauth",
+		Description:       "Retrieve the cluster-wide configuration of the OpenShift OAuth server, including its identity providers.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              oauthConfigResource.listConfig(),
+		Get:               oauthConfigResource.getConfig(),
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "identity_providers",
+				Description: "IdentityProviders is an ordered list of ways for a user to identify themselves, e.g. HTPasswd, LDAP or OpenID. When empty, no identities are provisioned for users.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.IdentityProviders"),
+			},
+			{
+				Name:        "identity_provider_types",
+				Description: "The types of the identity providers, e.g. [\"HTPasswd\", \"OpenID\"].",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.IdentityProviders").Transform(oauthIdentityProviderTypes),
+			},
+			{
+				Name:        "access_token_max_age_seconds",
+				Description: "AccessTokenMaxAgeSeconds defines the maximum age of access tokens. Null if unset, in which case the default of 24 hours is used.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Spec.TokenConfig.AccessTokenMaxAgeSeconds").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "access_token_inactivity_timeout",
+				Description: "AccessTokenInactivityTimeout defines the token inactivity timeout for tokens granted by any client, e.g. 5m0s. Null if tokens do not time out.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.TokenConfig.AccessTokenInactivityTimeout").Transform(oauthDurationToString),
+			},
+			{
+				Name:        "templates",
+				Description: "Templates allow customizing pages like the login page.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.Templates"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+// TRANSFORM FUNCTIONS
+
+// oauthIdentityProviderTypes :: returns the types of the identity providers of the OAuth config, in order
+func oauthIdentityProviderTypes(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	if d.Value == nil {
+		return nil, nil
+	}
+	identityProviders, ok := d.Value.([]configv1.IdentityProvider)
+	if !ok {
+		return nil, fmt.Errorf("invalid identity providers %T", d.Value)
+	}
+
+	types := []configv1.IdentityProviderType{}
+	for _, identityProvider := range identityProviders {
+		types = append(types, identityProvider.Type)
+	}
+	return types, nil
+}
+
+// oauthDurationToString :: formats the optional duration of the OAuth token config, e.g. 5m0s
+func oauthDurationToString(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	duration, ok := d.Value.(*v1.Duration)
+	if !ok || duration == nil {
+		return nil, nil
+	}
+	return duration.Duration.String(), nil
+}
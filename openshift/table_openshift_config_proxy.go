@@ -0,0 +1,89 @@
+package openshift
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// proxyConfigResource is the resource served by the openshift_config_proxy table
+var proxyConfigResource = &resourceTable[*configv1.Proxy, *configv1.ProxyList]{
+	name:     "openshift_config_proxy",
+	resource: configv1.GroupVersion.WithResource("proxies"),
+	scope:    clusterScope,
+	client: func(clients *openshiftClients, _ string) typedClient[*configv1.Proxy, *configv1.ProxyList] {
+		return clients.Config.Proxies()
+	},
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftConfigProxy(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_config_proxy",
+		Description:       "Retrieve the cluster-wide proxy configuration of OpenShift clusters.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              proxyConfigResource.listConfig(),
+		Get:               proxyConfigResource.getConfig(),
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "http_proxy",
+				Description: "HTTPProxy is the URL of the proxy for HTTP requests. Null if unset.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.HTTPProxy").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "https_proxy",
+				Description: "HTTPSProxy is the URL of the proxy for HTTPS requests. Null if unset.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.HTTPSProxy").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "no_proxy",
+				Description: "NoProxy is a comma-separated list of hostnames and/or CIDRs and/or IPs for which the proxy should not be used. Null if unset.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.NoProxy").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "readiness_endpoints",
+				Description: "ReadinessEndpoints is a list of endpoints used to verify readiness of the proxy.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Spec.ReadinessEndpoints"),
+			},
+			{
+				Name:        "trusted_ca",
+				Description: "Name of the ConfigMap in the openshift-config namespace containing the CA certificate bundle trusted for proxy connections.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.TrustedCA.Name").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "status_http_proxy",
+				Description: "The URL of the proxy for HTTP requests in effect in the cluster.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.HTTPProxy").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "status_https_proxy",
+				Description: "The URL of the proxy for HTTPS requests in effect in the cluster.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.HTTPSProxy").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "status_no_proxy",
+				Description: "The hostnames and/or CIDRs for which the proxy is not used in the cluster, including those the cluster adds to the spec.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.NoProxy").Transform(transform.NullIfZeroValue),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
@@ -0,0 +1,65 @@
+package openshift
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// schedulerConfigResource is the resource served by the openshift_config_scheduler table
+var schedulerConfigResource = &resourceTable[*configv1.Scheduler, *configv1.SchedulerList]{
+	name:     "openshift_config_scheduler",
+	resource: configv1.GroupVersion.WithResource("schedulers"),
+	scope:    clusterScope,
+	client: func(clients *openshiftClients, _ string) typedClient[*configv1.Scheduler, *configv1.SchedulerList] {
+		return clients.Config.Schedulers()
+	},
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftConfigScheduler(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_config_scheduler",
+		Description:       "Retrieve the cluster-wide scheduler configuration of OpenShift clusters.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              schedulerConfigResource.listConfig(),
+		Get:               schedulerConfigResource.getConfig(),
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "profile",
+				Description: "Profile sets which scheduling profile is used to make scheduling decisions for new pods, e.g. LowNodeUtilization, HighNodeUtilization or NoScoring. Null if unset, in which case LowNodeUtilization is used.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.Profile").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "masters_schedulable",
+				Description: "MastersSchedulable allows control plane nodes to be schedulable, so that workload pods can run on them.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Spec.MastersSchedulable"),
+			},
+			{
+				Name:        "default_node_selector",
+				Description: "DefaultNodeSelector sets the cluster-wide default node selector to restrict pod placement to specific nodes, e.g. type=user-node,region=east.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.DefaultNodeSelector").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "policy",
+				Description: "Name of the ConfigMap in the openshift-config namespace containing the deprecated scheduler policy.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Spec.Policy.Name").Transform(transform.NullIfZeroValue),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
@@ -2,28 +2,31 @@ package openshift
 
 import (
 	"context"
-	"strings"
 
-	client_v1 "github.com/openshift/client-go/apps/clientset/versioned/typed/apps/v1"
+	appsv1 "github.com/openshift/api/apps/v1"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// deploymentConfigResource is the resource served by the openshift_deployment_config table
+var deploymentConfigResource = &resourceTable[*appsv1.DeploymentConfig, *appsv1.DeploymentConfigList]{
+	name:     "openshift_deployment_config",
+	resource: appsv1.GroupVersion.WithResource("deploymentconfigs"),
+	scope:    namespacedScope,
+	client: func(clients *openshiftClients, namespace string) typedClient[*appsv1.DeploymentConfig, *appsv1.DeploymentConfigList] {
+		return clients.Apps.DeploymentConfigs(namespace)
+	},
+}
+
 //// TABLE DEFINITION
 func tableOpenShiftDeploymentConfig(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "openshift_deployment_config",
-		Description: "Retrieve information about OpenShift deployment configs.",
-		List: &plugin.ListConfig{
-			Hydrate:    listDeploymentConfigs,
-			KeyColumns: getCommonOptionalKeyQuals(),
-		},
-		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
-			Hydrate:    getDeploymentConfig,
-		},
+		Name:              "openshift_deployment_config",
+		Description:       "Retrieve information about OpenShift deployment configs.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              deploymentConfigResource.listConfig(),
+		Get:               deploymentConfigResource.getConfig(),
 		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "strategy",
@@ -144,89 +147,3 @@ func tableOpenShiftDeploymentConfig(ctx context.Context) *plugin.Table {
 		}),
 	}
 }
-
-// LIST FUNCTION
-func listDeploymentConfigs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	config, err := getClient(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_deployment_config.listDeploymentConfigs", "connection_error", err)
-		return nil, err
-	}
-	client, err := client_v1.NewForConfig(config)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_deployment_config.listDeploymentConfigs", "NewForConfig_error", err)
-		return nil, err
-	}
-
-	// Limiting the results
-	maxLimit := int64(1000)
-	if d.QueryContext.Limit != nil {
-		limit := *d.QueryContext.Limit
-		if limit < maxLimit {
-			maxLimit = limit
-		}
-	}
-
-	input := v1.ListOptions{
-		Limit: maxLimit,
-	}
-
-	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
-
-	if len(commonFieldSelectorValue) > 0 {
-		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
-	}
-
-	for {
-		response, err := client.DeploymentConfigs("").List(ctx, input)
-		if err != nil {
-			plugin.Logger(ctx).Error("openshift_deployment_config.listDeploymentConfigs", "api_error", err)
-			return nil, err
-		}
-		for _, deploymentConfig := range response.Items {
-			d.StreamListItem(ctx, deploymentConfig)
-
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
-			}
-		}
-		if response.Continue != "" {
-			input.Continue = response.Continue
-		} else {
-			break
-		}
-	}
-
-	return nil, nil
-}
-
-// HYDRATE FUNCTIONS
-func getDeploymentConfig(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	name := d.EqualsQualString("name")
-	namespace := d.EqualsQualString("namespace")
-
-	// Check if name or namespace is empty.
-	if name == "" || namespace == "" {
-		return nil, nil
-	}
-
-	config, err := getClient(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_deployment_config.getDeploymentConfig", "connection_error", err)
-		return nil, err
-	}
-	client, err := client_v1.NewForConfig(config)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_deployment_config.getDeploymentConfig", "NewForConfig_error", err)
-		return nil, err
-	}
-
-	deploymentConfig, err := client.DeploymentConfigs(namespace).Get(ctx, name, v1.GetOptions{})
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_deployment_config.getDeploymentConfig", "api_error", err)
-		return nil, err
-	}
-
-	return deploymentConfig, nil
-}
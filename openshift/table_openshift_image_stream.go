@@ -2,28 +2,37 @@ package openshift
 
 import (
 	"context"
-	"strings"
 
-	client_v1 "github.com/openshift/client-go/image/clientset/versioned/typed/image/v1"
+	imagev1 "github.com/openshift/api/image/v1"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// imageStreamSelectorQuals are the columns the API server can filter image streams by
+var imageStreamSelectorQuals = []selectorQual{
+	{Column: "docker_image_repository", Field: "status.dockerImageRepository"},
+}
+
+// imageStreamResource is the resource served by the openshift_image_stream table
+var imageStreamResource = &resourceTable[*imagev1.ImageStream, *imagev1.ImageStreamList]{
+	name:          "openshift_image_stream",
+	resource:      imagev1.GroupVersion.WithResource("imagestreams"),
+	scope:         namespacedScope,
+	selectorQuals: imageStreamSelectorQuals,
+	client: func(clients *openshiftClients, namespace string) typedClient[*imagev1.ImageStream, *imagev1.ImageStreamList] {
+		return clients.Image.ImageStreams(namespace)
+	},
+}
+
 //// TABLE DEFINITION
 func tableOpenShiftImageStream(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "openshift_image_stream",
-		Description: "Retrieve information about OpenShift image streams.",
-		List: &plugin.ListConfig{
-			Hydrate:    listImageStreams,
-			KeyColumns: getCommonOptionalKeyQuals(),
-		},
-		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
-			Hydrate:    getImageStream,
-		},
+		Name:              "openshift_image_stream",
+		Description:       "Retrieve information about OpenShift image streams.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              imageStreamResource.listConfig(),
+		Get:               imageStreamResource.getConfig(),
 		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "lookup_policy",
@@ -66,89 +75,3 @@ func tableOpenShiftImageStream(ctx context.Context) *plugin.Table {
 		}),
 	}
 }
-
-// LIST FUNCTION
-func listImageStreams(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	config, err := getClient(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_image_stream.listImageStreams", "connection_error", err)
-		return nil, err
-	}
-	client, err := client_v1.NewForConfig(config)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_image_stream.listImageStreams", "NewForStream_error", err)
-		return nil, err
-	}
-
-	// Limiting the results
-	maxLimit := int64(1000)
-	if d.QueryContext.Limit != nil {
-		limit := *d.QueryContext.Limit
-		if limit < maxLimit {
-			maxLimit = limit
-		}
-	}
-
-	input := v1.ListOptions{
-		Limit: maxLimit,
-	}
-
-	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
-
-	if len(commonFieldSelectorValue) > 0 {
-		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
-	}
-
-	for {
-		response, err := client.ImageStreams("").List(ctx, input)
-		if err != nil {
-			plugin.Logger(ctx).Error("openshift_image_stream.listImageStreams", "api_error", err)
-			return nil, err
-		}
-		for _, imageStream := range response.Items {
-			d.StreamListItem(ctx, imageStream)
-
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
-			}
-		}
-		if response.Continue != "" {
-			input.Continue = response.Continue
-		} else {
-			break
-		}
-	}
-
-	return nil, nil
-}
-
-// HYDRATE FUNCTIONS
-func getImageStream(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	name := d.EqualsQualString("name")
-	namespace := d.EqualsQualString("namespace")
-
-	// Check if name or namespace is empty.
-	if name == "" || namespace == "" {
-		return nil, nil
-	}
-
-	config, err := getClient(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_image_stream.getImageStream", "connection_error", err)
-		return nil, err
-	}
-	client, err := client_v1.NewForConfig(config)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_image_stream.getImageStream", "NewForStream_error", err)
-		return nil, err
-	}
-
-	imageStream, err := client.ImageStreams(namespace).Get(ctx, name, v1.GetOptions{})
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_image_stream.getImageStream", "api_error", err)
-		return nil, err
-	}
-
-	return imageStream, nil
-}
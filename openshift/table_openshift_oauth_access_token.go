@@ -3,25 +3,38 @@ package openshift
 import (
 	"context"
 
-	client_v1 "github.com/openshift/client-go/oauth/clientset/versioned/typed/oauth/v1"
+	oauthv1 "github.com/openshift/api/oauth/v1"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// oauthAccessTokenSelectorQuals are the columns the API server can filter OAuth access tokens by
+var oauthAccessTokenSelectorQuals = []selectorQual{
+	{Column: "client_name", Field: "clientName"},
+	{Column: "user_name", Field: "userName"},
+	{Column: "user_uid", Field: "userUID"},
+}
+
+// oauthAccessTokenResource is the resource served by the openshift_oauth_access_token table
+var oauthAccessTokenResource = &resourceTable[*oauthv1.OAuthAccessToken, *oauthv1.OAuthAccessTokenList]{
+	name:          "openshift_oauth_access_token",
+	resource:      oauthv1.GroupVersion.WithResource("oauthaccesstokens"),
+	scope:         clusterScope,
+	selectorQuals: oauthAccessTokenSelectorQuals,
+	client: func(clients *openshiftClients, _ string) typedClient[*oauthv1.OAuthAccessToken, *oauthv1.OAuthAccessTokenList] {
+		return clients.OAuth.OAuthAccessTokens()
+	},
+}
+
 //// TABLE DEFINITION
 func tableOpenShiftOAuthAccessToken(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "openshift_oauth_access_token",
-		Description: "Retrieve information about OpenShift OAuth access tokens.",
-		List: &plugin.ListConfig{
-			Hydrate: listOAuthAccessTokens,
-		},
-		Get: &plugin.GetConfig{
-			KeyColumns: plugin.SingleColumn("name"),
-			Hydrate:    getOAuthAccessToken,
-		},
+		Name:              "openshift_oauth_access_token",
+		Description:       "Retrieve information about OpenShift OAuth access tokens.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              oauthAccessTokenResource.listConfig(),
+		Get:               oauthAccessTokenResource.getConfig(),
 		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "client_name",
@@ -81,82 +94,3 @@ func tableOpenShiftOAuthAccessToken(ctx context.Context) *plugin.Table {
 		}),
 	}
 }
-
-// LIST FUNCTION
-func listOAuthAccessTokens(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	config, err := getClient(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_oauth_access_token.listOAuthAccessTokens", "connection_error", err)
-		return nil, err
-	}
-	client, err := client_v1.NewForConfig(config)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_oauth_access_token.listOAuthAccessTokens", "NewForNetwork_error", err)
-		return nil, err
-	}
-
-	// Limiting the results
-	maxLimit := int64(1000)
-	if d.QueryContext.Limit != nil {
-		limit := *d.QueryContext.Limit
-		if limit < maxLimit {
-			maxLimit = limit
-		}
-	}
-
-	input := v1.ListOptions{
-		Limit: maxLimit,
-	}
-
-	for {
-		response, err := client.OAuthAccessTokens().List(ctx, input)
-		if err != nil {
-			plugin.Logger(ctx).Error("openshift_oauth_access_token.listOAuthAccessTokens", "api_error", err)
-			return nil, err
-		}
-		for _, token := range response.Items {
-			d.StreamListItem(ctx, token)
-
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
-			}
-		}
-		if response.Continue != "" {
-			input.Continue = response.Continue
-		} else {
-			break
-		}
-	}
-
-	return nil, nil
-}
-
-// HYDRATE FUNCTIONS
-func getOAuthAccessToken(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	name := d.EqualsQualString("name")
-
-	// Check if name is empty.
-	if name == "" {
-		return nil, nil
-	}
-
-	config, err := getClient(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_oauth_access_token.getOAuthAccessToken", "connection_error", err)
-		return nil, err
-	}
-	client, err := client_v1.NewForConfig(config)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_oauth_access_token.getOAuthAccessToken", "NewForNetwork_error", err)
-		return nil, err
-	}
-
-	clusterNetwork, err := client.OAuthAccessTokens().Get(ctx, name, v1.GetOptions{})
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_oauth_access_token.getOAuthAccessToken", "api_error", err)
-		return nil, err
-	}
-
-	return clusterNetwork, nil
-}
@@ -2,28 +2,38 @@ package openshift
 
 import (
 	"context"
-	"strings"
 
-	client_v1 "github.com/openshift/client-go/route/clientset/versioned/typed/route/v1"
+	routev1 "github.com/openshift/api/route/v1"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// routeSelectorQuals are the columns the API server can filter routes by
+var routeSelectorQuals = []selectorQual{
+	{Column: "host", Field: "spec.host"},
+	{Column: "path", Field: "spec.path"},
+}
+
+// routeResource is the resource served by the openshift_route table
+var routeResource = &resourceTable[*routev1.Route, *routev1.RouteList]{
+	name:          "openshift_route",
+	resource:      routev1.GroupVersion.WithResource("routes"),
+	scope:         namespacedScope,
+	selectorQuals: routeSelectorQuals,
+	client: func(clients *openshiftClients, namespace string) typedClient[*routev1.Route, *routev1.RouteList] {
+		return clients.Route.Routes(namespace)
+	},
+}
+
 //// TABLE DEFINITION
 func tableOpenShiftRoute(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "openshift_route",
-		Description: "Retrieve information about OpenShift routes.",
-		List: &plugin.ListConfig{
-			Hydrate:    listRoutes,
-			KeyColumns: getCommonOptionalKeyQuals(),
-		},
-		Get: &plugin.GetConfig{
-			KeyColumns: plugin.AllColumns([]string{"name", "namespace"}),
-			Hydrate:    getRoute,
-		},
+		Name:              "openshift_route",
+		Description:       "Retrieve information about OpenShift routes.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              routeResource.listConfig(),
+		Get:               routeResource.getConfig(),
 		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "host",
@@ -90,89 +100,3 @@ func tableOpenShiftRoute(ctx context.Context) *plugin.Table {
 		}),
 	}
 }
-
-// LIST FUNCTION
-func listRoutes(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	config, err := getClient(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_route.listRoutes", "connection_error", err)
-		return nil, err
-	}
-	client, err := client_v1.NewForConfig(config)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_route.listRoutes", "NewForConfig_error", err)
-		return nil, err
-	}
-
-	// Limiting the results
-	maxLimit := int64(1000)
-	if d.QueryContext.Limit != nil {
-		limit := *d.QueryContext.Limit
-		if limit < maxLimit {
-			maxLimit = limit
-		}
-	}
-
-	input := v1.ListOptions{
-		Limit: maxLimit,
-	}
-
-	commonFieldSelectorValue := getCommonOptionalKeyQualsValueForFieldSelector(d)
-
-	if len(commonFieldSelectorValue) > 0 {
-		input.FieldSelector = strings.Join(commonFieldSelectorValue, ",")
-	}
-
-	for {
-		response, err := client.Routes("").List(ctx, input)
-		if err != nil {
-			plugin.Logger(ctx).Error("openshift_route.listRoutes", "api_error", err)
-			return nil, err
-		}
-		for _, route := range response.Items {
-			d.StreamListItem(ctx, route)
-
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
-			}
-		}
-		if response.Continue != "" {
-			input.Continue = response.Continue
-		} else {
-			break
-		}
-	}
-
-	return nil, nil
-}
-
-// HYDRATE FUNCTIONS
-func getRoute(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	name := d.EqualsQualString("name")
-	namespace := d.EqualsQualString("namespace")
-
-	// Check if name or namespace is empty.
-	if name == "" || namespace == "" {
-		return nil, nil
-	}
-
-	config, err := getClient(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_route.getRoute", "connection_error", err)
-		return nil, err
-	}
-	client, err := client_v1.NewForConfig(config)
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_route.getRoute", "NewForConfig_error", err)
-		return nil, err
-	}
-
-	route, err := client.Routes(namespace).Get(ctx, name, v1.GetOptions{})
-	if err != nil {
-		plugin.Logger(ctx).Error("openshift_route.getRoute", "api_error", err)
-		return nil, err
-	}
-
-	return route, nil
-}
@@ -0,0 +1,189 @@
+package openshift
+
+import (
+	"context"
+
+	securityv1 "github.com/openshift/api/security/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// securityContextConstraintResource is the resource served by the openshift_security_context_constraint table
+var securityContextConstraintResource = &resourceTable[*securityv1.SecurityContextConstraints, *securityv1.SecurityContextConstraintsList]{
+	name:     "openshift_security_context_constraint",
+	resource: securityv1.GroupVersion.WithResource("securitycontextconstraints"),
+	scope:    clusterScope,
+	client: func(clients *openshiftClients, _ string) typedClient[*securityv1.SecurityContextConstraints, *securityv1.SecurityContextConstraintsList] {
+		return clients.Security.SecurityContextConstraints()
+	},
+}
+
+//// TABLE DEFINITION
+func tableOpenShiftSecurityContextConstraint(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "openshift_security_context_constraint",
+		Description:       "Retrieve information about OpenShift security context constraints.",
+		GetMatrixItemFunc: BuildContextList,
+		List:              securityContextConstraintResource.listConfig(),
+		Get:               securityContextConstraintResource.getConfig(),
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "priority",
+				Description: "Priority influences the sort order of SCCs when evaluating which SCCs to try first for a given pod request based on access in the users and groups fields. The higher the value, the higher the priority. An unset value is considered a 0 priority.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "allow_privileged_container",
+				Description: "AllowPrivilegedContainer determines if a container can request to be run as privileged.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "default_add_capabilities",
+				Description: "DefaultAddCapabilities is the default set of capabilities that will be added to the container unless the pod spec specifically drops the capability.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "required_drop_capabilities",
+				Description: "RequiredDropCapabilities are the capabilities that will be dropped from the container. These are required to be dropped and cannot be added.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "allowed_capabilities",
+				Description: "AllowedCapabilities is a list of capabilities that can be requested to add to the container. The wildcard '*' allows all capabilities.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "allow_host_dir_volume_plugin",
+				Description: "AllowHostDirVolumePlugin determines if the policy allows containers to use the HostDir volume plugin.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "volumes",
+				Description: "Volumes is a white list of allowed volume plugins. The wildcard '*' allows all volume plugins.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "allowed_flex_volumes",
+				Description: "AllowedFlexVolumes is a whitelist of allowed Flexvolumes. Empty or nil indicates that all Flexvolumes may be used.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "allow_host_network",
+				Description: "AllowHostNetwork determines if the policy allows the use of HostNetwork in the pod spec.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "allow_host_ports",
+				Description: "AllowHostPorts determines if the policy allows host ports in the containers.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "allow_host_pid",
+				Description: "AllowHostPID determines if the policy allows host pid in the containers.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("AllowHostPID"),
+			},
+			{
+				Name:        "allow_host_ipc",
+				Description: "AllowHostIPC determines if the policy allows host ipc in the containers.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("AllowHostIPC"),
+			},
+			{
+				Name:        "default_allow_privilege_escalation",
+				Description: "DefaultAllowPrivilegeEscalation controls the default setting for whether a process can gain more privileges than its parent process.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "allow_privilege_escalation",
+				Description: "AllowPrivilegeEscalation determines if a pod can request to allow privilege escalation. If unspecified, defaults to true.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "se_linux_context_strategy",
+				Description: "Type of the strategy that dictates the allowable labels that may be set, e.g. MustRunAs or RunAsAny.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SELinuxContext.Type"),
+			},
+			{
+				Name:        "se_linux_context",
+				Description: "SELinuxContext is the strategy that will dictate what labels will be set in the SecurityContext, including its SELinux options.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("SELinuxContext"),
+			},
+			{
+				Name:        "run_as_user_strategy",
+				Description: "Type of the strategy that dictates the allowable RunAsUser values that may be set, e.g. MustRunAs, MustRunAsRange, MustRunAsNonRoot or RunAsAny.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RunAsUser.Type"),
+			},
+			{
+				Name:        "run_as_user",
+				Description: "RunAsUser is the strategy that will dictate what RunAsUser is used in the SecurityContext, including its UID and UID range.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "supplemental_groups_strategy",
+				Description: "Type of the strategy that dictates what supplemental groups are used by the SecurityContext, e.g. MustRunAs or RunAsAny.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SupplementalGroups.Type"),
+			},
+			{
+				Name:        "supplemental_groups",
+				Description: "SupplementalGroups is the strategy that will dictate what supplemental groups are used by the SecurityContext, including the allowed ranges.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "fs_group_strategy",
+				Description: "Type of the strategy that dictates what fs group is used by the SecurityContext, e.g. MustRunAs or RunAsAny.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("FSGroup.Type"),
+			},
+			{
+				Name:        "fs_group",
+				Description: "FSGroup is the strategy that will dictate what fs group is used by the SecurityContext, including the allowed ranges.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("FSGroup"),
+			},
+			{
+				Name:        "read_only_root_filesystem",
+				Description: "ReadOnlyRootFilesystem when set to true will force containers to run with a read only root file system.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "users",
+				Description: "The users who have permissions to use this security context constraints.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "groups",
+				Description: "The groups that have permission to use this security context constraints.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "seccomp_profiles",
+				Description: "SeccompProfiles lists the allowed profiles that may be set for the pod or container's seccomp annotations. The wildcard '*' allows all profiles.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "allowed_unsafe_sysctls",
+				Description: "AllowedUnsafeSysctls is a list of explicitly allowed unsafe sysctls.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "forbidden_sysctls",
+				Description: "ForbiddenSysctls is a list of explicitly forbidden sysctls.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
@@ -4,17 +4,29 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/go-homedir"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
-	"gopkg.in/yaml.v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
-	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 func getClient(ctx context.Context, d *plugin.QueryData) (*rest.Config, error) {
@@ -26,76 +38,454 @@ func getClient(ctx context.Context, d *plugin.QueryData) (*rest.Config, error) {
 	return conn.(*rest.Config), nil
 }
 
-var GetNewClientCached = plugin.HydrateFunc(GetNewClientUncached).Memoize()
+var GetNewClientCached = plugin.HydrateFunc(GetNewClientUncached).Memoize(memoize.WithCacheKeyFunction(getNewClientCacheKey))
+
+// getNewClientCacheKey :: cache the client per kubeconfig context of the connection
+func getNewClientCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return fmt.Sprintf("GetNewClient-%s", getMatrixContextName(ctx)), nil
+}
+
+// openshiftAPIGroups are API groups which are only served by OpenShift clusters.
+var openshiftAPIGroups = []string{
+	"project.openshift.io",
+	"route.openshift.io",
+	"config.openshift.io",
+}
+
+// Supported values of the auth_mode connection config argument
+const (
+	authModeAuto        = "auto"
+	authModeKubeconfig  = "kubeconfig"
+	authModeCredentials = "credentials"
+	authModeInCluster   = "in_cluster"
+)
+
+// inClusterTokenPath is where the service account token is mounted in pods
+const inClusterTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
 
 // GetNewClientUncached :: gets client for querying openshift apis for the provided context
 func GetNewClientUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (any, error) {
 	// get openshift config info
 	openshiftConfig := GetConfig(d.Connection)
 
-	// Set default loader and overriding rules
-	loader := &clientcmd.ClientConfigLoadingRules{}
-	overrides := &clientcmd.ConfigOverrides{}
+	authMode, err := getAuthMode(openshiftConfig)
+	if err != nil {
+		return nil, err
+	}
+	plugin.Logger(ctx).Debug("GetNewClientUncached", "auth_mode", authMode)
+	if len(openshiftConfig.ConfigContexts) > 0 && authMode != authModeKubeconfig {
+		return nil, fmt.Errorf("config_contexts is only supported with the %s auth_mode, not %s", authModeKubeconfig, authMode)
+	}
 
-	// default kube config path
-	var configPath = "~/.kube/config"
+	switch forbiddenMode := getForbiddenMode(openshiftConfig); forbiddenMode {
+	case forbiddenModeError, forbiddenModePerProject:
+	default:
+		return nil, fmt.Errorf("invalid forbidden_mode %q, supported values are %s and %s", forbiddenMode, forbiddenModeError, forbiddenModePerProject)
+	}
+	switch listConsistency := getListConsistency(openshiftConfig); listConsistency {
+	case listConsistencyQuorum, listConsistencyCached:
+	default:
+		return nil, fmt.Errorf("invalid list_consistency %q, supported values are %s and %s", listConsistency, listConsistencyQuorum, listConsistencyCached)
+	}
+	if _, err := getInformerIdleTimeout(openshiftConfig); err != nil {
+		return nil, err
+	}
+	if err := getNamespaceFilter(d).validate(); err != nil {
+		return nil, err
+	}
 
-	if openshiftConfig.ConfigPath != nil {
-		configPath = *openshiftConfig.ConfigPath
-	} else if v := os.Getenv("KUBE_CONFIG"); v != "" {
-		configPath = v
-	} else if v := os.Getenv("KUBECONFIG"); v != "" {
-		configPath = v
+	var restconfig *rest.Config
+	var contextName string
+	switch authMode {
+	case authModeCredentials:
+		restconfig, err = getCredentialsRestConfig(openshiftConfig, getConfigValue(openshiftConfig.Host, "OPENSHIFT_HOST"))
+	case authModeInCluster:
+		restconfig, err = getInClusterRestConfig()
+	default:
+		restconfig, contextName, err = getKubeconfigRestConfig(openshiftConfig, getMatrixContextName(ctx))
 	}
-	path, err := homedir.Expand(configPath)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := os.ReadFile(path)
-	if err != nil {
+	if err := applyClientSettings(openshiftConfig, restconfig); err != nil {
+		return nil, err
+	}
+	if err := applyImpersonation(openshiftConfig, restconfig); err != nil {
 		return nil, err
 	}
 
-	var config map[string]interface{}
-	err = yaml.Unmarshal(data, &config)
-	if err != nil {
+	// return err if the client does not point at an openshift cluster
+	if err := verifyOpenShiftCluster(restconfig); err != nil {
+		// a forbidden discovery request means the credentials are not allowed to impersonate
+		if restconfig.Impersonate.UserName != "" && apierrors.IsForbidden(err) {
+			err = fmt.Errorf("impersonation of user %q is not permitted for the connection credentials: %w", restconfig.Impersonate.UserName, err)
+		}
+		if contextName != "" {
+			return nil, fmt.Errorf("context %q: %w", contextName, err)
+		}
 		return nil, err
 	}
 
-	// by default plugin will consider the current context available
-	var flag = 0
-	for k, v := range config {
-		if k == "current-context" && strings.Contains(v.(string), "openshift") {
-			overrides.CurrentContext = v.(string)
-			overrides.Context = clientcmdapi.Context{}
-			flag = 1
-			break
+	return restconfig, nil
+}
+
+// applyClientSettings :: applies the rate limiting, timeout and proxy arguments to the rest.Config
+func applyClientSettings(openshiftConfig openshiftConfig, restconfig *rest.Config) error {
+	if openshiftConfig.QPS != nil {
+		if *openshiftConfig.QPS <= 0 {
+			return fmt.Errorf("invalid qps %v: must be positive", *openshiftConfig.QPS)
+		}
+		restconfig.QPS = float32(*openshiftConfig.QPS)
+	}
+	if openshiftConfig.Burst != nil {
+		if *openshiftConfig.Burst <= 0 {
+			return fmt.Errorf("invalid burst %d: must be positive", *openshiftConfig.Burst)
+		}
+		restconfig.Burst = *openshiftConfig.Burst
+	}
+
+	if openshiftConfig.RequestTimeout != nil {
+		timeout, err := time.ParseDuration(*openshiftConfig.RequestTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid request_timeout %q: %w", *openshiftConfig.RequestTimeout, err)
+		}
+		restconfig.Timeout = timeout
+	}
+
+	if openshiftConfig.ProxyURL != nil {
+		proxyURL, err := url.Parse(*openshiftConfig.ProxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy_url %q: %w", *openshiftConfig.ProxyURL, err)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return fmt.Errorf("invalid proxy_url %q, the scheme must be http, https or socks5", *openshiftConfig.ProxyURL)
+		}
+		restconfig.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return nil
+}
+
+// applyImpersonation :: sets the user, groups and uid to impersonate on the rest.Config
+func applyImpersonation(openshiftConfig openshiftConfig, restconfig *rest.Config) error {
+	if openshiftConfig.ImpersonateUser == nil {
+		if len(openshiftConfig.ImpersonateGroups) > 0 || openshiftConfig.ImpersonateUID != nil {
+			return errors.New("impersonate_user must be set when impersonate_groups or impersonate_uid are configured")
+		}
+		return nil
+	}
+
+	restconfig.Impersonate = rest.ImpersonationConfig{
+		UserName: *openshiftConfig.ImpersonateUser,
+		Groups:   openshiftConfig.ImpersonateGroups,
+	}
+	if openshiftConfig.ImpersonateUID != nil {
+		restconfig.Impersonate.UID = *openshiftConfig.ImpersonateUID
+	}
+
+	return nil
+}
+
+// getAuthMode :: resolves the auth_mode connection config argument, detecting the mode to use if unset or "auto"
+func getAuthMode(openshiftConfig openshiftConfig) (string, error) {
+	authMode := getConfigValue(openshiftConfig.AuthMode, "OPENSHIFT_AUTH_MODE")
+	switch authMode {
+	case authModeKubeconfig, authModeCredentials, authModeInCluster:
+		return authMode, nil
+	case "", authModeAuto:
+	default:
+		return "", fmt.Errorf("invalid auth_mode %q, supported values are %s, %s, %s and %s", authMode, authModeAuto, authModeKubeconfig, authModeCredentials, authModeInCluster)
+	}
+
+	// connection config arguments take precedence over environment variables, the host and credential arguments
+	// over the kubeconfig arguments, so a connection's kubeconfig is not overridden by OPENSHIFT_* variables set for other tools
+	if hasCredentialsArguments(openshiftConfig) {
+		return authModeCredentials, nil
+	}
+	if hasKubeconfigArguments(openshiftConfig) {
+		return authModeKubeconfig, nil
+	}
+	if getConfigValue(openshiftConfig.Host, "OPENSHIFT_HOST") != "" || hasCredentialsConfig(openshiftConfig) {
+		return authModeCredentials, nil
+	}
+
+	// an explicitly configured kubeconfig is always used
+	configPaths, explicit := getKubeconfigPaths(openshiftConfig)
+	if explicit {
+		return authModeKubeconfig, nil
+	}
+
+	// fall back to the pod service account if the default kubeconfig does not exist
+	paths, err := expandKubeconfigPaths(configPaths)
+	if err != nil {
+		return "", err
+	}
+	if !slices.ContainsFunc(paths, fileExists) && isInCluster() {
+		return authModeInCluster, nil
+	}
+
+	return authModeKubeconfig, nil
+}
+
+// isInCluster :: checks whether the plugin is running in a pod with a mounted service account token
+func isInCluster() bool {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") == "" {
+		return false
+	}
+	return fileExists(inClusterTokenPath)
+}
+
+// fileExists :: checks whether a file exists at the given path
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// getInClusterRestConfig :: builds the rest.Config from the service account of the pod the plugin is running in
+func getInClusterRestConfig() (*rest.Config, error) {
+	restconfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load in-cluster service account config: %w", err)
+	}
+
+	return restconfig, nil
+}
+
+// getKubeconfigPaths :: returns the kubeconfig paths to load, in order of precedence, and whether they were explicitly configured
+func getKubeconfigPaths(openshiftConfig openshiftConfig) ([]string, bool) {
+	if len(openshiftConfig.ConfigPaths) > 0 {
+		return openshiftConfig.ConfigPaths, true
+	} else if openshiftConfig.ConfigPath != nil {
+		return filepath.SplitList(*openshiftConfig.ConfigPath), true
+	} else if v := os.Getenv("KUBE_CONFIG"); v != "" {
+		return filepath.SplitList(v), true
+	} else if v := os.Getenv("KUBECONFIG"); v != "" {
+		return filepath.SplitList(v), true
+	}
+
+	// default kube config path
+	return []string{"~/.kube/config"}, false
+}
+
+// expandKubeconfigPaths :: expands the home directory in the kubeconfig paths, dropping empty entries
+func expandKubeconfigPaths(configPaths []string) ([]string, error) {
+	paths := []string{}
+	for _, configPath := range configPaths {
+		if configPath == "" {
+			continue
+		}
+		path, err := homedir.Expand(configPath)
+		if err != nil {
+			return nil, err
 		}
+		paths = append(paths, path)
 	}
+	return paths, nil
+}
+
+// getKubeconfigLoadingRules :: returns the loading rules for the configured kubeconfig files, along with the files for use in messages
+func getKubeconfigLoadingRules(openshiftConfig openshiftConfig) (*clientcmd.ClientConfigLoadingRules, string, error) {
+	loader := &clientcmd.ClientConfigLoadingRules{}
 
-	// return err if no openshift config available
-	if flag == 0 {
-		return nil, errors.New("openshift cluster details is unavailable in: " + path)
+	configPaths, _ := getKubeconfigPaths(openshiftConfig)
+	paths, err := expandKubeconfigPaths(configPaths)
+	if err != nil {
+		return nil, "", err
 	}
+	path := strings.Join(paths, string(filepath.ListSeparator))
 
-	loader.ExplicitPath = path
+	// a single file must exist, whereas multiple files are merged using the
+	// same precedence rules as oc and kubectl, where the first file to set a value wins
+	if len(paths) == 1 {
+		loader.ExplicitPath = paths[0]
+	} else {
+		if !slices.ContainsFunc(paths, fileExists) {
+			return nil, "", fmt.Errorf("none of the kubeconfig files %s exist", path)
+		}
+		loader.Precedence = paths
+	}
 
-	// override context if provided in the connection config file
-	if openshiftConfig.ConfigContext != nil {
+	return loader, path, nil
+}
+
+// getKubeconfigRestConfig :: builds the rest.Config from the kubeconfig files for the given context, or the configured one if empty.
+// The name of the resolved context is returned along with the config.
+func getKubeconfigRestConfig(openshiftConfig openshiftConfig, contextName string) (*rest.Config, string, error) {
+	loader, path, err := getKubeconfigLoadingRules(openshiftConfig)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Set overriding rules
+	overrides := &clientcmd.ConfigOverrides{}
+
+	// override context if provided by the matrix item or in the connection config file
+	if contextName != "" {
+		overrides.CurrentContext = contextName
+	} else if openshiftConfig.ConfigContext != nil {
 		overrides.CurrentContext = *openshiftConfig.ConfigContext
-		overrides.Context = clientcmdapi.Context{}
 	}
 
 	osConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
 
+	// Resolve the requested context before building the client
+	rawConfig, err := osConfig.RawConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to load kubeconfig %s: %w", path, err)
+	}
+	contextName = rawConfig.CurrentContext
+	if overrides.CurrentContext != "" {
+		contextName = overrides.CurrentContext
+	}
+	if contextName == "" {
+		return nil, "", fmt.Errorf("no current-context is set in kubeconfig %s and config_context is not specified", path)
+	}
+	if _, ok := rawConfig.Contexts[contextName]; !ok {
+		return nil, "", fmt.Errorf("context %q not found in kubeconfig %s", contextName, path)
+	}
+
 	// Get a rest.Config from the osConfig file.
 	restconfig, err := osConfig.ClientConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to build client for context %q: %w", contextName, err)
+	}
+
+	return restconfig, contextName, nil
+}
+
+// hasCredentialsArguments :: checks whether the host or any of the credential arguments are set in the connection config,
+// ignoring environment variables
+func hasCredentialsArguments(openshiftConfig openshiftConfig) bool {
+	arguments := []*string{
+		openshiftConfig.Host,
+		openshiftConfig.Token,
+		openshiftConfig.TokenFile,
+		openshiftConfig.ClientCertificate,
+		openshiftConfig.ClientCertificateData,
+		openshiftConfig.ClientKey,
+		openshiftConfig.ClientKeyData,
+	}
+	for _, argument := range arguments {
+		if argument != nil && *argument != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasKubeconfigArguments :: checks whether any of the kubeconfig arguments are set in the connection config
+func hasKubeconfigArguments(openshiftConfig openshiftConfig) bool {
+	return openshiftConfig.ConfigPath != nil || len(openshiftConfig.ConfigPaths) > 0 ||
+		openshiftConfig.ConfigContext != nil || len(openshiftConfig.ConfigContexts) > 0
+}
+
+// hasCredentialsConfig :: checks whether any of the token or certificate based credentials are set
+func hasCredentialsConfig(openshiftConfig openshiftConfig) bool {
+	values := []string{
+		getConfigValue(openshiftConfig.Token, "OPENSHIFT_TOKEN"),
+		getConfigValue(openshiftConfig.TokenFile, "OPENSHIFT_TOKEN_FILE"),
+		getConfigValue(openshiftConfig.ClientCertificate, "OPENSHIFT_CLIENT_CERTIFICATE"),
+		getConfigValue(openshiftConfig.ClientCertificateData, "OPENSHIFT_CLIENT_CERTIFICATE_DATA"),
+		getConfigValue(openshiftConfig.ClientKey, "OPENSHIFT_CLIENT_KEY"),
+		getConfigValue(openshiftConfig.ClientKeyData, "OPENSHIFT_CLIENT_KEY_DATA"),
+	}
+	for _, value := range values {
+		if value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// getCredentialsRestConfig :: builds the rest.Config directly from the host and credential arguments without loading a kubeconfig
+func getCredentialsRestConfig(openshiftConfig openshiftConfig, host string) (*rest.Config, error) {
+	if host == "" {
+		return nil, errors.New("host must be set when token or client certificate credentials are configured")
+	}
+
+	tokenFile, err := getConfigPathValue(openshiftConfig.TokenFile, "OPENSHIFT_TOKEN_FILE")
+	if err != nil {
+		return nil, err
+	}
+	certFile, err := getConfigPathValue(openshiftConfig.ClientCertificate, "OPENSHIFT_CLIENT_CERTIFICATE")
+	if err != nil {
+		return nil, err
+	}
+	keyFile, err := getConfigPathValue(openshiftConfig.ClientKey, "OPENSHIFT_CLIENT_KEY")
+	if err != nil {
+		return nil, err
+	}
+	caFile, err := getConfigPathValue(openshiftConfig.CertificateAuthority, "OPENSHIFT_CERTIFICATE_AUTHORITY")
 	if err != nil {
 		return nil, err
 	}
 
-	return restconfig, err
+	insecure := false
+	if openshiftConfig.InsecureSkipTLSVerify != nil {
+		insecure = *openshiftConfig.InsecureSkipTLSVerify
+	} else if v := os.Getenv("OPENSHIFT_INSECURE_SKIP_TLS_VERIFY"); v != "" {
+		insecure, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OPENSHIFT_INSECURE_SKIP_TLS_VERIFY value %q: %w", v, err)
+		}
+	}
+
+	restconfig := &rest.Config{
+		Host:            host,
+		BearerToken:     getConfigValue(openshiftConfig.Token, "OPENSHIFT_TOKEN"),
+		BearerTokenFile: tokenFile,
+		TLSClientConfig: rest.TLSClientConfig{
+			Insecure: insecure,
+			CertFile: certFile,
+			CertData: []byte(getConfigValue(openshiftConfig.ClientCertificateData, "OPENSHIFT_CLIENT_CERTIFICATE_DATA")),
+			KeyFile:  keyFile,
+			KeyData:  []byte(getConfigValue(openshiftConfig.ClientKeyData, "OPENSHIFT_CLIENT_KEY_DATA")),
+			CAFile:   caFile,
+			CAData:   []byte(getConfigValue(openshiftConfig.CertificateAuthorityData, "OPENSHIFT_CERTIFICATE_AUTHORITY_DATA")),
+		},
+	}
+
+	return restconfig, nil
+}
+
+// getConfigValue :: returns the connection config value if set, else the value of the environment variable
+func getConfigValue(value *string, envVar string) string {
+	if value != nil {
+		return *value
+	}
+	return os.Getenv(envVar)
+}
+
+// getConfigPathValue :: same as getConfigValue, with the home directory expanded in the resulting path
+func getConfigPathValue(value *string, envVar string) (string, error) {
+	path := getConfigValue(value, envVar)
+	if path == "" {
+		return "", nil
+	}
+	return homedir.Expand(path)
+}
+
+// verifyOpenShiftCluster checks the API discovery of the cluster for any of the OpenShift specific API groups
+func verifyOpenShiftCluster(config *rest.Config) error {
+	client, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	groups, err := client.ServerGroups()
+	if err != nil {
+		return fmt.Errorf("unable to query API discovery on %s: %w", config.Host, err)
+	}
+	for _, group := range groups.Groups {
+		if slices.Contains(openshiftAPIGroups, group.Name) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s is not an OpenShift cluster, none of the API groups %s are served", config.Host, strings.Join(openshiftAPIGroups, ", "))
 }
 
 func v1TimeToRFC3339(_ context.Context, d *transform.TransformData) (interface{}, error) {
@@ -116,23 +506,195 @@ func v1TimeToRFC3339(_ context.Context, d *transform.TransformData) (interface{}
 	}
 }
 
-func getCommonOptionalKeyQuals() []*plugin.KeyColumn {
-	return []*plugin.KeyColumn{
-		{Name: "name", Require: plugin.Optional},
-		{Name: "namespace", Require: plugin.Optional},
+// clusterConditionStatus :: returns the status of the cluster status condition of the type in the param as a bool,
+// nil if the condition is missing or its status is Unknown
+func clusterConditionStatus(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	condition, err := findClusterCondition(d)
+	if condition == nil || err != nil {
+		return nil, err
+	}
+
+	switch condition.Status {
+	case configv1.ConditionTrue:
+		return true, nil
+	case configv1.ConditionFalse:
+		return false, nil
+	default:
+		return nil, nil
 	}
 }
 
-func getCommonOptionalKeyQualsValueForFieldSelector(d *plugin.QueryData) []string {
-	fieldSelectors := []string{}
+// clusterConditionReason :: returns the reason of the cluster status condition of the type in the param
+func clusterConditionReason(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	condition, err := findClusterCondition(d)
+	if condition == nil || err != nil {
+		return nil, err
+	}
+	return condition.Reason, nil
+}
+
+// clusterConditionMessage :: returns the message of the cluster status condition of the type in the param
+func clusterConditionMessage(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	condition, err := findClusterCondition(d)
+	if condition == nil || err != nil {
+		return nil, err
+	}
+	return condition.Message, nil
+}
 
-	if d.EqualsQualString("name") != "" {
-		fieldSelectors = append(fieldSelectors, fmt.Sprintf("metadata.name=%v", d.EqualsQualString("name")))
+// findClusterCondition :: finds the condition of the type in the param among the cluster status conditions of the value
+func findClusterCondition(d *transform.TransformData) (*configv1.ClusterOperatorStatusCondition, error) {
+	conditions, ok := d.Value.([]configv1.ClusterOperatorStatusCondition)
+	if !ok {
+		return nil, fmt.Errorf("invalid cluster status conditions %T", d.Value)
+	}
+	conditionType, ok := d.Param.(configv1.ClusterStatusConditionType)
+	if !ok {
+		return nil, fmt.Errorf("invalid cluster status condition type %T", d.Param)
 	}
 
-	if d.EqualsQualString("namespace") != "" {
-		fieldSelectors = append(fieldSelectors, fmt.Sprintf("metadata.namespace=%v", d.EqualsQualString("namespace")))
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// selectorQual maps a column to the field selector, or label, the API server can filter it by
+type selectorQual struct {
+	Column string
+	// Field is the field selector path, e.g. status.phase
+	Field string
+	// Label is the label key, for values the API server only supports filtering by through labels
+	Label string
+}
+
+// commonSelectorQuals are supported as field selectors by all resources
+var commonSelectorQuals = []selectorQual{
+	{Column: "name", Field: "metadata.name"},
+	{Column: "namespace", Field: "metadata.namespace"},
+}
+
+// getCommonOptionalKeyQuals :: key quals for listing namespaced resources, along with any table specific selector quals
+func getCommonOptionalKeyQuals(selectorQuals ...selectorQual) []*plugin.KeyColumn {
+	keyColumns := []*plugin.KeyColumn{
+		{Name: "name", Operators: []string{"=", "<>"}, Require: plugin.Optional},
+		{Name: "namespace", Operators: []string{"=", "<>"}, Require: plugin.Optional},
+		{Name: "label_selector", Require: plugin.Optional},
+	}
+	return append(keyColumns, getSelectorOptionalKeyQuals(selectorQuals)...)
+}
+
+// getClusterScopedOptionalKeyQuals :: key quals for listing cluster scoped resources, along with any table specific selector quals
+func getClusterScopedOptionalKeyQuals(selectorQuals ...selectorQual) []*plugin.KeyColumn {
+	keyColumns := []*plugin.KeyColumn{
+		{Name: "name", Operators: []string{"=", "<>"}, Require: plugin.Optional},
+		{Name: "label_selector", Require: plugin.Optional},
+	}
+	return append(keyColumns, getSelectorOptionalKeyQuals(selectorQuals)...)
+}
+
+func getSelectorOptionalKeyQuals(selectorQuals []selectorQual) []*plugin.KeyColumn {
+	keyColumns := []*plugin.KeyColumn{}
+	for _, selectorQual := range selectorQuals {
+		keyColumns = append(keyColumns, &plugin.KeyColumn{Name: selectorQual.Column, Operators: []string{"=", "<>"}, Require: plugin.Optional})
+	}
+	return keyColumns
+}
+
+// getCommonGetKeyQuals :: key quals for get calls, where the optional label selector is matched against the fetched object
+func getCommonGetKeyQuals(columns ...string) []*plugin.KeyColumn {
+	return append(plugin.AllColumns(columns), &plugin.KeyColumn{Name: "label_selector", Require: plugin.Optional})
+}
+
+// getLabelSelectorQualValue :: parses the label_selector qual, which uses the Kubernetes label selector syntax, e.g. "app=web,tier in (frontend)",
+// and adds the quals of any table specific selector quals which are mapped to labels
+func getLabelSelectorQualValue(d *plugin.QueryData, selectorQuals ...selectorQual) (labels.Selector, error) {
+	selector := labels.Everything()
+	if d.Quals["label_selector"] != nil {
+		for _, qual := range d.Quals["label_selector"].Quals {
+			if qual.Value.GetListValue() != nil {
+				return nil, fmt.Errorf("label_selector does not support IN lists, use a set based selector instead, e.g. label_selector = 'app in (web,api)'")
+			}
+		}
+	}
+	// several label selectors must all match, so their requirements are combined
+	for _, value := range getSelectorQualValues(d, "label_selector", quals.QualOperatorEqual) {
+		valueSelector, err := labels.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label_selector %q: %w", value, err)
+		}
+		requirements, _ := valueSelector.Requirements()
+		selector = selector.Add(requirements...)
+	}
+
+	for _, selectorQual := range selectorQuals {
+		if selectorQual.Label == "" {
+			continue
+		}
+		operators := []struct {
+			qual     string
+			selector selection.Operator
+		}{
+			{quals.QualOperatorEqual, selection.Equals},
+			{quals.QualOperatorNotEqual, selection.NotEquals},
+		}
+		for _, operator := range operators {
+			for _, value := range getSelectorQualValues(d, selectorQual.Column, operator.qual) {
+				// values which are not valid label values, e.g. too long, are left to be filtered client side
+				requirement, err := labels.NewRequirement(selectorQual.Label, operator.selector, []string{value})
+				if err != nil {
+					continue
+				}
+				selector = selector.Add(*requirement)
+			}
+		}
+	}
+
+	return selector, nil
+}
+
+// matchesLabelSelectorQual :: checks the labels of an object against the label_selector qual, as label selectors are not supported by get calls
+func matchesLabelSelectorQual(d *plugin.QueryData, objectLabels map[string]string) (bool, error) {
+	selector, err := getLabelSelectorQualValue(d)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(objectLabels)), nil
+}
+
+// getCommonOptionalKeyQualsValueForFieldSelector :: builds the field selectors for the equal and not equal quals of
+// the name and namespace columns, along with any table specific selector quals, e.g. "status=Failed,metadata.name!=build-1"
+func getCommonOptionalKeyQualsValueForFieldSelector(d *plugin.QueryData, selectorQuals ...selectorQual) []string {
+	fieldSelectors := []string{}
+
+	for _, selectorQual := range append(commonSelectorQuals, selectorQuals...) {
+		if selectorQual.Field == "" {
+			continue
+		}
+		for _, value := range getSelectorQualValues(d, selectorQual.Column, quals.QualOperatorEqual) {
+			fieldSelectors = append(fieldSelectors, fields.OneTermEqualSelector(selectorQual.Field, value).String())
+		}
+		for _, value := range getSelectorQualValues(d, selectorQual.Column, quals.QualOperatorNotEqual) {
+			fieldSelectors = append(fieldSelectors, fields.OneTermNotEqualSelector(selectorQual.Field, value).String())
+		}
 	}
 
 	return fieldSelectors
 }
+
+// getSelectorQualValues :: returns the string values of the quals of the column with the operator, ignoring IN lists
+func getSelectorQualValues(d *plugin.QueryData, column string, operator string) []string {
+	values := []string{}
+	if d.Quals[column] == nil {
+		return values
+	}
+	for _, qual := range d.Quals[column].Quals {
+		if qual.Operator != operator || qual.Value.GetListValue() != nil {
+			continue
+		}
+		values = append(values, qual.Value.GetStringValue())
+	}
+	return values
+}
@@ -0,0 +1,145 @@
+package openshift
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+func TestV1TimeToRFC3339(t *testing.T) {
+	timestamp := v1.NewTime(time.Date(2023, 6, 7, 13, 42, 13, 0, time.UTC))
+	var nilTime *v1.Time
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "nil", value: nil, want: nil},
+		{name: "time", value: timestamp, want: "2023-06-07T13:42:13Z"},
+		{name: "time pointer", value: &timestamp, want: "2023-06-07T13:42:13Z"},
+		{name: "nil time pointer", value: nilTime, want: nil},
+		{name: "zero time", value: v1.Time{}, want: nil},
+		{name: "invalid type", value: "2023-06-07", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := v1TimeToRFC3339(newTestContext(), &transform.TransformData{Value: test.value})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("v1TimeToRFC3339() returned %v, want error %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("v1TimeToRFC3339() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestClusterConditionTransforms(t *testing.T) {
+	conditions := []configv1.ClusterOperatorStatusCondition{
+		{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue, Reason: "AsExpected"},
+		{Type: configv1.OperatorDegraded, Status: configv1.ConditionFalse},
+		{Type: configv1.OperatorProgressing, Status: configv1.ConditionUnknown, Reason: "Rolling", Message: "rolling out 2 of 3"},
+	}
+
+	tests := []struct {
+		name          string
+		transform     transform.TransformFunc
+		value         interface{}
+		conditionType configv1.ClusterStatusConditionType
+		want          interface{}
+		wantErr       bool
+	}{
+		{name: "true status", transform: clusterConditionStatus, value: conditions, conditionType: configv1.OperatorAvailable, want: true},
+		{name: "false status", transform: clusterConditionStatus, value: conditions, conditionType: configv1.OperatorDegraded, want: false},
+		{name: "unknown status", transform: clusterConditionStatus, value: conditions, conditionType: configv1.OperatorProgressing, want: nil},
+		{name: "missing condition", transform: clusterConditionStatus, value: conditions, conditionType: configv1.OperatorUpgradeable, want: nil},
+		{name: "no conditions", transform: clusterConditionStatus, value: []configv1.ClusterOperatorStatusCondition(nil), conditionType: configv1.OperatorAvailable, want: nil},
+		{name: "reason", transform: clusterConditionReason, value: conditions, conditionType: configv1.OperatorProgressing, want: "Rolling"},
+		{name: "message", transform: clusterConditionMessage, value: conditions, conditionType: configv1.OperatorProgressing, want: "rolling out 2 of 3"},
+		{name: "missing reason", transform: clusterConditionReason, value: conditions, conditionType: configv1.OperatorUpgradeable, want: nil},
+		{name: "invalid type", transform: clusterConditionStatus, value: "Available", conditionType: configv1.OperatorAvailable, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.transform(newTestContext(), &transform.TransformData{Value: test.value, Param: test.conditionType})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("transform returned %v, want error %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("transform = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetAuthMode(t *testing.T) {
+	value := func(value string) *string { return &value }
+
+	tests := []struct {
+		name   string
+		config openshiftConfig
+		env    map[string]string
+		want   string
+	}{
+		{name: "host argument", config: openshiftConfig{Host: value("https://api.example.com:6443"), ConfigPath: value("~/.kube/prod")}, want: authModeCredentials},
+		{name: "config path argument", config: openshiftConfig{ConfigPath: value("~/.kube/prod")}, want: authModeKubeconfig},
+		{name: "config path argument over environment credentials", config: openshiftConfig{ConfigPath: value("~/.kube/prod")}, env: map[string]string{"OPENSHIFT_TOKEN": "token"}, want: authModeKubeconfig},
+		{name: "config context argument over environment host", config: openshiftConfig{ConfigContext: value("prod")}, env: map[string]string{"OPENSHIFT_HOST": "https://api.example.com:6443"}, want: authModeKubeconfig},
+		{name: "environment credentials", env: map[string]string{"OPENSHIFT_HOST": "https://api.example.com:6443", "OPENSHIFT_TOKEN": "token"}, want: authModeCredentials},
+		{name: "explicit auth mode", config: openshiftConfig{AuthMode: value(authModeCredentials), ConfigPath: value("~/.kube/prod")}, want: authModeCredentials},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, envVar := range []string{"OPENSHIFT_AUTH_MODE", "OPENSHIFT_HOST", "OPENSHIFT_TOKEN"} {
+				t.Setenv(envVar, test.env[envVar])
+			}
+			got, err := getAuthMode(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("getAuthMode() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestApplyClientSettings(t *testing.T) {
+	float := func(value float64) *float64 { return &value }
+	integer := func(value int) *int { return &value }
+	value := func(value string) *string { return &value }
+
+	tests := []struct {
+		name    string
+		config  openshiftConfig
+		wantErr string
+	}{
+		{name: "valid", config: openshiftConfig{QPS: float(50), Burst: integer(100), RequestTimeout: value("30s")}},
+		{name: "zero qps", config: openshiftConfig{QPS: float(0)}, wantErr: "invalid qps 0: must be positive"},
+		{name: "negative qps", config: openshiftConfig{QPS: float(-1)}, wantErr: "invalid qps -1: must be positive"},
+		{name: "negative burst", config: openshiftConfig{Burst: integer(-10)}, wantErr: "invalid burst -10: must be positive"},
+		{name: "invalid request timeout", config: openshiftConfig{RequestTimeout: value("soon")}, wantErr: `invalid request_timeout "soon"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := applyClientSettings(test.config, &rest.Config{})
+			if test.wantErr == "" && err != nil {
+				t.Fatal(err)
+			}
+			if test.wantErr != "" && (err == nil || !strings.Contains(err.Error(), test.wantErr)) {
+				t.Errorf("applyClientSettings() returned %v, want %q", err, test.wantErr)
+			}
+		})
+	}
+}